@@ -0,0 +1,194 @@
+package seltabl
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+)
+
+// Alignment is how the cells selected for each field are grouped into rows.
+type Alignment int
+
+const (
+	// AlignByIndex puts the nth cell selected for each field in the nth row.
+	//
+	// Fields selecting different numbers of cells are reported to the
+	// function given to WithWarnings. It is the default.
+	AlignByIndex Alignment = iota
+	// AlignStrict is AlignByIndex failing with an ErrMisaligned when fields
+	// select different numbers of cells.
+	AlignStrict
+	// AlignByRow puts each cell in the row of its row element, so a field
+	// missing a cell in some row leaves only that row unset.
+	//
+	// The row element of a cell is its closest table row, or its parent if
+	// it is not in a table row. Rows are in document order.
+	AlignByRow
+)
+
+// fieldMatch is the cells selected for a field of a struct.
+type fieldMatch struct {
+	index     int                  // index of the field in the struct
+	cfg       *SelectorConfig      // selector config of the field
+	cells     *goquery.Selection   // cells selected by the data selector
+	rows      []*goquery.Selection // row element of each cell
+	positions []int                // index of the row of each cell
+}
+
+// selectsCells reports whether the field's data selector selects its cells,
+// rather than its group, key, pivot or columns.
+func selectsCells(field reflect.StructField, cfg *SelectorConfig) bool {
+	return cfg.DataSelector != "" && cfg.Group == "" && cfg.Key == "" &&
+		cfg.Pivot == "" && !isColumnsField(field)
+}
+
+// matchFields selects the cells of each field of the struct type with a
+// data selector.
+func matchFields(
+	doc *goquery.Document,
+	dType reflect.Type,
+) ([]*fieldMatch, error) {
+	matches := make([]*fieldMatch, 0, dType.NumField())
+	for i := 0; i < dType.NumField(); i++ {
+		cfg := NewSelectorConfig(dType.Field(i).Tag)
+		if !selectsCells(dType.Field(i), cfg) {
+			continue
+		}
+		dataRows := find(doc.Selection, cfg.DataSelector)
+		if dataRows.Length() <= 0 && !cfg.HasDefault {
+			return nil, ErrSelectorNotFound{
+				Typ:   dType,
+				Field: dType.Field(i),
+				Cfg:   cfg,
+			}
+		}
+		if cfg.HeadSelector != "" && cfg.HeadSelector != "-" {
+			removeFiltered(dataRows, cfg.HeadSelector)
+		}
+		m := &fieldMatch{
+			index: i,
+			cfg:   cfg,
+			cells: dataRows,
+			rows:  make([]*goquery.Selection, dataRows.Length()),
+		}
+		for j := range m.rows {
+			m.rows[j] = rowElement(dataRows.Eq(j))
+		}
+		matches = append(matches, m)
+	}
+	return matches, nil
+}
+
+// alignsByRow reports whether the cells of the struct type are aligned by
+// row element, as with AlignByRow, which structs with a stride or a field
+// filling down always use.
+func alignsByRow(dType reflect.Type, rc *rowConfig, o *options) bool {
+	return o.alignment == AlignByRow || rc.Stride > 1 || hasFillDown(dType)
+}
+
+// alignRows sets the positions of the cells of each match, returning the
+// row element of each row.
+//
+// order is the index of each node of the document in document order. It is
+// only used when aligning by row element.
+func alignRows(
+	dType reflect.Type,
+	matches []*fieldMatch,
+	order map[*html.Node]int,
+	rc *rowConfig,
+	o *options,
+) ([]*goquery.Selection, error) {
+	if alignsByRow(dType, rc, o) {
+		return alignByRow(matches, order), nil
+	}
+	rows := make([]*goquery.Selection, 0)
+	for _, m := range matches {
+		m.positions = make([]int, m.cells.Length())
+		for j := range m.positions {
+			m.positions[j] = j
+		}
+		rows = append(rows, m.rows[min(len(rows), len(m.rows)):]...)
+	}
+	err := checkAlignment(dType, matches)
+	switch {
+	case err == nil:
+	case o.alignment == AlignStrict:
+		return nil, err
+	case o.warn != nil:
+		o.warn(err)
+	}
+	return rows, nil
+}
+
+// alignByRow groups the cells of each match by their row element.
+func alignByRow(
+	matches []*fieldMatch,
+	order map[*html.Node]int,
+) []*goquery.Selection {
+	rows := make([]*goquery.Selection, 0)
+	seen := make(map[*html.Node]bool)
+	for _, m := range matches {
+		for _, row := range m.rows {
+			node := rowNode(row)
+			if !seen[node] {
+				seen[node] = true
+				rows = append(rows, row)
+			}
+		}
+	}
+	sort.SliceStable(rows, func(a, b int) bool {
+		return order[rowNode(rows[a])] < order[rowNode(rows[b])]
+	})
+	positions := make(map[*html.Node]int, len(rows))
+	for j, row := range rows {
+		positions[rowNode(row)] = j
+	}
+	for _, m := range matches {
+		m.positions = make([]int, len(m.rows))
+		for j, row := range m.rows {
+			m.positions[j] = positions[rowNode(row)]
+		}
+	}
+	return rows
+}
+
+// rowNode returns the node of a row element.
+func rowNode(row *goquery.Selection) *html.Node {
+	if row.Length() == 0 {
+		return nil
+	}
+	return row.Get(0)
+}
+
+// documentOrder returns the index of each node of the document in document
+// order.
+func documentOrder(doc *goquery.Document) map[*html.Node]int {
+	order := make(map[*html.Node]int)
+	doc.Find("*").Each(func(i int, s *goquery.Selection) {
+		order[s.Get(0)] = i
+	})
+	return order
+}
+
+// checkAlignment returns an ErrMisaligned if the fields without a default
+// tag selected different numbers of cells.
+func checkAlignment(dType reflect.Type, matches []*fieldMatch) error {
+	err := ErrMisaligned{Typ: dType}
+	aligned := true
+	for _, m := range matches {
+		if m.cfg.HasDefault {
+			continue
+		}
+		if len(err.Counts) > 0 && err.Counts[0] != m.cells.Length() {
+			aligned = false
+		}
+		err.Fields = append(err.Fields, dType.Field(m.index))
+		err.Counts = append(err.Counts, m.cells.Length())
+	}
+	if aligned {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,86 @@
+package seltabl
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// misalignedHTML is a table whose second row is missing its score.
+const misalignedHTML = `
+	<table>
+		<tr> <td class="team">Ames</td> <td class="score">21</td> </tr>
+		<tr> <td class="team">Iowa</td> </tr>
+		<tr> <td class="team">Ohio</td> <td class="score">14</td> </tr>
+	</table>`
+
+// Score is a struct decoded from misalignedHTML.
+type Score struct {
+	Team  string `dSel:"td.team"`
+	Score int    `dSel:"td.score"`
+}
+
+// TestNewFromString_Alignment tests aligning cells into rows.
+func TestNewFromString_Alignment(t *testing.T) {
+	t.Parallel()
+	t.Run("index", func(t *testing.T) {
+		t.Parallel()
+		var warnings []error
+		result, err := NewFromString[Score](
+			misalignedHTML,
+			WithWarnings(func(err error) { warnings = append(warnings, err) }),
+		)
+		assert.NoError(t, err)
+		assert.Equal(t, []Score{
+			{Team: "Ames", Score: 21},
+			{Team: "Iowa", Score: 14},
+			{Team: "Ohio"},
+		}, result)
+		assert.Len(t, warnings, 1)
+		var misaligned ErrMisaligned
+		assert.True(t, errors.As(warnings[0], &misaligned))
+		assert.Equal(t, []int{3, 2}, misaligned.Counts)
+	})
+	t.Run("strict", func(t *testing.T) {
+		t.Parallel()
+		_, err := NewFromString[Score](misalignedHTML, WithAlignment(AlignStrict))
+		var misaligned ErrMisaligned
+		assert.True(t, errors.As(err, &misaligned), "expected ErrMisaligned, got %v", err)
+		assert.EqualError(
+			t,
+			err,
+			"fields of seltabl.Score select different numbers of cells: Team=3, Score=2",
+		)
+	})
+	t.Run("strict aligned", func(t *testing.T) {
+		t.Parallel()
+		result, err := NewFromString[TestieStruct](basicHTML, WithAlignment(AlignStrict))
+		assert.NoError(t, err)
+		assert.NotEmpty(t, result)
+	})
+	t.Run("row", func(t *testing.T) {
+		t.Parallel()
+		result, err := NewFromString[Score](misalignedHTML, WithAlignment(AlignByRow))
+		assert.NoError(t, err)
+		assert.Equal(t, []Score{
+			{Team: "Ames", Score: 21},
+			{Team: "Iowa"},
+			{Team: "Ohio", Score: 14},
+		}, result)
+	})
+	t.Run("row default", func(t *testing.T) {
+		t.Parallel()
+		type Defaulted struct {
+			Team  string `dSel:"td.team"`
+			Score int    `dSel:"td.score" default:"-1"`
+			Meta  Meta
+		}
+		result, err := NewFromString[Defaulted](misalignedHTML, WithAlignment(AlignByRow))
+		assert.NoError(t, err)
+		assert.Len(t, result, 3)
+		assert.Equal(t, -1, result[1].Score)
+		assert.Equal(t, 14, result[2].Score)
+		assert.Contains(t, result[1].Meta.HTML, "Iowa")
+	})
+}
@@ -0,0 +1,122 @@
+package seltabl
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+const (
+	// alternativeSeparator separates the alternatives of a selector chain,
+	// such as "table#stats td || table.wikitable td".
+	alternativeSeparator = "||"
+)
+
+// SelectorChoice is the alternative chosen from the selector chain of a
+// field's header or data selector.
+type SelectorChoice struct {
+	// Field is the field whose tag has the chain.
+	Field reflect.StructField
+	// Tag is the tag of the chain, hSel or dSel.
+	Tag string
+	// Index is the index of the chosen alternative in the chain, or -1 if
+	// no alternative matched.
+	Index int
+	// Selector is the chosen alternative.
+	Selector string
+}
+
+// splitAlternatives returns the alternatives of a selector chain, ignoring
+// separators within quotes.
+func splitAlternatives(selector string) []string {
+	alternatives := make([]string, 0, 1)
+	var quote byte
+	start := 0
+	for i := 0; i < len(selector); i++ {
+		switch c := selector[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case strings.HasPrefix(selector[i:], alternativeSeparator):
+			alternatives = append(alternatives, strings.TrimSpace(selector[start:i]))
+			i += len(alternativeSeparator) - 1
+			start = i + 1
+		}
+	}
+	return append(alternatives, strings.TrimSpace(selector[start:]))
+}
+
+// chooseAlternative returns the first alternative of the selector chain
+// matching within the selection and its index, or the first alternative and
+// -1 if none match. A selector without alternatives is returned as is.
+func chooseAlternative(sel *goquery.Selection, selector string) (string, int) {
+	alternatives := splitAlternatives(selector)
+	if len(alternatives) == 1 {
+		return alternatives[0], 0
+	}
+	for i, alternative := range alternatives {
+		if findSelector(sel, alternative).Length() > 0 {
+			return alternative, i
+		}
+	}
+	return alternatives[0], -1
+}
+
+// document returns a selection of the document node of the selection.
+func document(sel *goquery.Selection) *goquery.Selection {
+	root := rootNode(sel)
+	if root == nil {
+		return sel
+	}
+	return sel.FilterNodes().AddNodes(root)
+}
+
+// chooseSelectors reports the alternatives chosen from the selector chains
+// of the header and data selectors of the struct's fields to the selector
+// choice hook of the options.
+//
+// The alternatives are chosen within the scope, the document or the row
+// elements whose cells are selected relative to them. It returns the chosen
+// data selectors keyed by field name, or nil if no field has a chain.
+func chooseSelectors(
+	scope *goquery.Selection,
+	dType reflect.Type,
+	o *options,
+) map[string]string {
+	var chosen map[string]string
+	for i := 0; i < dType.NumField(); i++ {
+		field := dType.Field(i)
+		cfg := NewSelectorConfig(field.Tag)
+		for _, chain := range []struct {
+			tag      string
+			selector string
+		}{
+			{selectorHeaderTag, cfg.HeadSelector},
+			{selectorDataTag, cfg.DataSelector},
+		} {
+			if len(splitAlternatives(chain.selector)) < 2 {
+				continue
+			}
+			selector, index := chooseAlternative(scope, chain.selector)
+			if chain.tag == selectorDataTag {
+				if chosen == nil {
+					chosen = make(map[string]string)
+				}
+				chosen[field.Name] = selector
+			}
+			if o.choice != nil {
+				o.choice(SelectorChoice{
+					Field:    field,
+					Tag:      chain.tag,
+					Index:    index,
+					Selector: selector,
+				})
+			}
+		}
+	}
+	return chosen
+}
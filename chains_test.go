@@ -0,0 +1,112 @@
+package seltabl
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSplitAlternatives tests splitting selector chains.
+func TestSplitAlternatives(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		selector string
+		want     []string
+	}{
+		{selector: "td", want: []string{"td"}},
+		{selector: "table#a td || table.b td", want: []string{"table#a td", "table.b td"}},
+		{selector: `td[title="a||b"] || th`, want: []string{`td[title="a||b"]`, "th"}},
+		{selector: "xpath://td[.='||'] ||td", want: []string{"xpath://td[.='||']", "td"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, splitAlternatives(tt.selector))
+		})
+	}
+}
+
+// TestNewFromString_Chains tests fallback selector chains.
+func TestNewFromString_Chains(t *testing.T) {
+	t.Parallel()
+	type Row struct {
+		Team string `hSel:"table#stats th:nth-child(1) || table.wikitable th:nth-child(1)" dSel:"table#stats td:nth-child(1) || table.wikitable td:nth-child(1)"`
+		Wins int    `dSel:"table#stats td:nth-child(2) || xpath://table[@class='wikitable']//td[2]"`
+		Meta Meta
+	}
+	var choices []SelectorChoice
+	rows, err := NewFromString[Row](`
+		<table class="wikitable">
+			<tr> <th>Team</th> <th>Wins</th> </tr>
+			<tr> <td>Ames</td> <td>12</td> </tr>
+			<tr> <td>Iowa</td> <td>7</td> </tr>
+		</table>`,
+		WithSelectorChoices(func(choice SelectorChoice) {
+			choices = append(choices, choice)
+		}),
+	)
+	assert.NoError(t, err)
+	if !assert.Len(t, rows, 2) {
+		return
+	}
+	assert.Equal(t, "Iowa", rows[1].Team)
+	assert.Equal(t, 7, rows[1].Wins)
+	assert.Equal(t, map[string]string{
+		"Team": "table.wikitable td:nth-child(1)",
+		"Wins": "xpath://table[@class='wikitable']//td[2]",
+	}, rows[0].Meta.Selectors)
+	if !assert.Len(t, choices, 3) {
+		return
+	}
+	assert.Equal(t, "Team", choices[0].Field.Name)
+	assert.Equal(t, selectorHeaderTag, choices[0].Tag)
+	assert.Equal(t, 1, choices[0].Index)
+	assert.Equal(t, "table.wikitable th:nth-child(1)", choices[0].Selector)
+
+	type Missing struct {
+		Team string `dSel:"table#stats td || table#other td"`
+	}
+	choices = nil
+	_, err = NewFromString[Missing](`<table><tr><td>a</td></tr></table>`,
+		WithSelectorChoices(func(choice SelectorChoice) {
+			choices = append(choices, choice)
+		}),
+	)
+	assert.Error(t, err)
+	assert.Equal(t, []SelectorChoice{{
+		Field:    reflect.TypeOf(Missing{}).Field(0),
+		Tag:      selectorDataTag,
+		Index:    -1,
+		Selector: "table#stats td",
+	}}, choices)
+
+	type Card struct {
+		_     struct{} `rowSel:"div.card"`
+		Name  string   `dSel:"h1 || h2"`
+		Price string   `dSel:"span.price || span.sale" default:"-"`
+		Meta  Meta
+	}
+	choices = nil
+	cards, err := NewFromString[Card](`
+		<h1>Shop</h1>
+		<div class="card"> <h2>Kettle</h2> <span class="price">24</span> </div>
+		<div class="card"> <h2>Teapot</h2> <span class="sale">9</span> </div>`,
+		WithSelectorChoices(func(choice SelectorChoice) {
+			choices = append(choices, choice)
+		}),
+	)
+	assert.NoError(t, err)
+	if !assert.Len(t, cards, 2) {
+		return
+	}
+	assert.Equal(t, "Kettle", cards[0].Name)
+	assert.Equal(t, "24", cards[0].Price)
+	assert.Equal(t, "Teapot", cards[1].Name)
+	assert.Equal(t, "-", cards[1].Price)
+	assert.Equal(t, map[string]string{"Name": "h2", "Price": "span.price"}, cards[0].Meta.Selectors)
+	if assert.Len(t, choices, 2) {
+		assert.Equal(t, 1, choices[0].Index)
+		assert.Equal(t, 0, choices[1].Index)
+	}
+}
@@ -0,0 +1,137 @@
+package seltabl
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+)
+
+// columns is the cells of a map field collecting the columns not bound to
+// other fields, keyed by header text.
+//
+// The header selector (hSel) of the field selects the header cells of every
+// column and its data selector (dSel) their cells. Cells belong to the
+// header in the same column.
+//
+// Example:
+//
+//	type Player struct {
+//		Name  string            `dSel:"tr:not(:first-child) td:nth-child(1)"`
+//		Extra map[string]string `hSel:"tr:first-child th" dSel:"tr:not(:first-child) td"`
+//	}
+type columns struct {
+	index   int                                 // index of the field in the struct
+	cfg     *SelectorConfig                     // selector config of the field
+	headers map[int]string                      // header text of each column
+	cells   map[*html.Node][]*goquery.Selection // cells of each row
+}
+
+// isColumnsField reports whether the field collects unbound columns, a map
+// keyed by string with a header or data selector. Untagged maps are ignored
+// like other untagged fields.
+func isColumnsField(field reflect.StructField) bool {
+	_, head := field.Tag.Lookup(selectorHeaderTag)
+	_, data := field.Tag.Lookup(selectorDataTag)
+	return field.Type.Kind() == reflect.Map &&
+		field.Type.Key().Kind() == reflect.String && (head || data)
+}
+
+// matchColumns selects the header cells and cells of each map field of the
+// struct type.
+func matchColumns(doc *goquery.Document, dType reflect.Type) ([]*columns, error) {
+	matched := make([]*columns, 0)
+	for i := 0; i < dType.NumField(); i++ {
+		field := dType.Field(i)
+		if !isColumnsField(field) {
+			continue
+		}
+		cfg := NewSelectorConfig(field.Tag)
+		if cfg.HeadSelector == "" || cfg.DataSelector == "" {
+			return nil, fmt.Errorf(
+				"map field %s needs a header and a data selector",
+				field.Name,
+			)
+		}
+		c := &columns{
+			index:   i,
+			cfg:     cfg,
+			headers: make(map[int]string),
+			cells:   make(map[*html.Node][]*goquery.Selection),
+		}
+		find(doc.Selection, cfg.HeadSelector).Each(func(_ int, header *goquery.Selection) {
+			c.headers[column(header)] = strings.Join(strings.Fields(header.Text()), " ")
+		})
+		find(doc.Selection, cfg.DataSelector).Each(func(_ int, cell *goquery.Selection) {
+			row := rowNode(rowElement(cell))
+			c.cells[row] = append(c.cells[row], cell)
+		})
+		matched = append(matched, c)
+	}
+	return matched, nil
+}
+
+// boundColumns returns the columns of the cells selected by the fields of
+// the struct and its pivot value field.
+func boundColumns(matches []*fieldMatch, p *pivot) map[int]bool {
+	bound := make(map[int]bool)
+	for _, m := range matches {
+		m.cells.Each(func(_ int, cell *goquery.Selection) {
+			bound[column(cell)] = true
+		})
+	}
+	if p != nil {
+		for _, cells := range p.cells {
+			for _, cell := range cells {
+				bound[column(cell)] = true
+			}
+		}
+	}
+	return bound
+}
+
+// setColumns sets the map field of each row to the values of the cells in
+// columns that are not bound and have a header.
+func (c *columns) setColumns(
+	results reflect.Value,
+	rows []*goquery.Selection,
+	bound map[int]bool,
+	base *url.URL,
+) error {
+	field := results.Type().Elem().Field(c.index)
+	selector := newSelector(c.cfg, base)
+	for j, row := range rows {
+		values := reflect.MakeMap(field.Type)
+		for _, cell := range c.cells[rowNode(row)] {
+			col := column(cell)
+			header, ok := c.headers[col]
+			if !ok || bound[col] {
+				continue
+			}
+			value, err := selector.Select(cell)
+			if err != nil {
+				return fmt.Errorf("failed to run selector: %w", err)
+			}
+			value, err = applyTransforms(c.cfg.Transforms, value)
+			if err != nil {
+				return fmt.Errorf("failed to transform value: %w", err)
+			}
+			elem := reflect.New(field.Type.Elem()).Elem()
+			err = setFieldValue(elem.Kind(), value, &elem, c.cfg)
+			if err != nil {
+				return fmt.Errorf(
+					"failed to set column %s of field %s: %w",
+					header,
+					field.Name,
+					err,
+				)
+			}
+			values.SetMapIndex(reflect.ValueOf(header).Convert(field.Type.Key()), elem)
+		}
+		results.Index(j).Field(c.index).Set(values)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package seltabl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// columnsHTML is a table with ad-hoc columns.
+const columnsHTML = `
+	<table>
+		<tr> <th>Name</th> <th>Team</th> <th>Goals</th> <th>Shots on  goal</th> </tr>
+		<tr> <td>Ada</td> <td>Ames</td> <td>3</td> <td>7</td> </tr>
+		<tr> <td>Bea</td> <td>Iowa</td> <td>1</td> <td>4</td> </tr>
+	</table>`
+
+// TestNewFromString_Columns tests map fields collecting unbound columns.
+func TestNewFromString_Columns(t *testing.T) {
+	t.Parallel()
+	type Player struct {
+		Name  string            `dSel:"tr:not(:first-child) td:nth-child(1)"`
+		Team  string            `dSel:"tr:not(:first-child) td:nth-child(2)"`
+		Extra map[string]string `hSel:"tr:first-child th" dSel:"tr:not(:first-child) td"`
+	}
+	result, err := NewFromString[Player](columnsHTML)
+	assert.NoError(t, err)
+	assert.Equal(t, []Player{
+		{Name: "Ada", Team: "Ames", Extra: map[string]string{"Goals": "3", "Shots on goal": "7"}},
+		{Name: "Bea", Team: "Iowa", Extra: map[string]string{"Goals": "1", "Shots on goal": "4"}},
+	}, result)
+
+	type Stats struct {
+		Name  string         `dSel:"tr:not(:first-child) td:nth-child(1)"`
+		Stats map[string]int `hSel:"tr:first-child th:nth-child(n+3)" dSel:"tr:not(:first-child) td"`
+	}
+	stats, err := NewFromString[Stats](columnsHTML)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"Goals": 1, "Shots on goal": 4}, stats[1].Stats)
+
+	type Invalid struct {
+		Name  string         `dSel:"tr:not(:first-child) td:nth-child(1)"`
+		Stats map[string]int `hSel:"tr:first-child th" dSel:"tr:not(:first-child) td"`
+	}
+	_, err = NewFromString[Invalid](columnsHTML)
+	assert.ErrorContains(t, err, "failed to set column Team of field Stats")
+
+	type Missing struct {
+		Name  string            `dSel:"tr td:nth-child(1)"`
+		Extra map[string]string `dSel:"tr td"`
+	}
+	_, err = NewFromString[Missing](columnsHTML)
+	assert.ErrorContains(t, err, "needs a header and a data selector")
+
+	type Untagged struct {
+		Name  string `dSel:"tr:not(:first-child) td:nth-child(1)"`
+		Cache map[string]int
+	}
+	untagged, err := NewFromString[Untagged](columnsHTML)
+	assert.NoError(t, err)
+	assert.Equal(t, []Untagged{{Name: "Ada"}, {Name: "Bea"}}, untagged)
+}
@@ -2,11 +2,32 @@ package seltabl
 
 import (
 	"reflect"
+	"strconv"
 )
 
 var (
 	// cSels is a list of supported control selectors
-	cSels = []string{ctlInnerTextSelector, ctlAttrSelector}
+	cSels = []string{
+		ctlInnerTextSelector,
+		ctlAttrSelector,
+		ctlURLSelector,
+		ctlSpacesSelector,
+		ctlInnerHTMLSelector,
+		ctlOuterHTMLSelector,
+		ctlOwnTextSelector,
+		ctlCountSelector,
+		ctlRegexSelector,
+	}
+
+	// queryless is the set of control selectors that do not use a query
+	// selector, or only optionally use one.
+	queryless = map[string]bool{
+		ctlSpacesSelector:    true,
+		ctlInnerHTMLSelector: true,
+		ctlOuterHTMLSelector: true,
+		ctlOwnTextSelector:   true,
+		ctlCountSelector:     true,
+	}
 )
 
 const (
@@ -19,30 +40,165 @@ const (
 
 	// selectorControlTag is the tag used to signify selecting aspects of a cell
 	selectorControlTag = "ctl"
+	// selectorFollowTag is the tag used to follow a link in a cell to a page
+	// decoded into the field.
+	selectorFollowTag = "follow"
+	// transformTag is the tag used to transform the selected value of a cell
+	// before it is validated and converted to the field's type.
+	transformTag = "fn"
+
+	// validateMustTag is the tag used to ensure raw text is present in the
+	// document.
+	validateMustTag = "must"
+	// validateRequiredTag is the tag used to ensure a cell is not empty.
+	validateRequiredTag = "required"
+	// validatePatternTag is the tag used to ensure a cell matches a regular
+	// expression.
+	validatePatternTag = "pattern"
+	// validateMinTag is the tag used to ensure a number or length is at
+	// least a minimum.
+	validateMinTag = "min"
+	// validateMaxTag is the tag used to ensure a number or length is at most
+	// a maximum.
+	validateMaxTag = "max"
+	// validateOneOfTag is the tag used to ensure a cell is one of a space
+	// separated list of values.
+	validateOneOfTag = "oneof"
+	// numberLocaleTag is the tag used to name the locale numbers in a cell
+	// are written in, such as "de" for 1.234,56.
+	numberLocaleTag = "locale"
+	// mapTag is the tag used to map the value of a cell onto another, such
+	// as "W=win,L=loss".
+	mapTag = "map"
+	// boolTruthyTag is the tag used to list the space separated values of a
+	// cell that are true.
+	boolTruthyTag = "true"
+	// boolFalsyTag is the tag used to list the space separated values of a
+	// cell that are false.
+	boolFalsyTag = "false"
+	// defaultTag is the tag used to give the value of an empty or missing
+	// cell.
+	defaultTag = "default"
+	// fillTag is the tag used to fill empty or missing cells, such as with
+	// the cell above for "down".
+	fillTag = "fill"
+	// selectorGroupTag is the tag used to select group cells, such as
+	// section header rows, that fill the field of each row after them.
+	selectorGroupTag = "group"
+	// selectorSubRowTag is the tag used to select which of the rows of a
+	// struct with a stride the field's cells are in, counting from 0.
+	selectorSubRowTag = "sub"
+	// selectorKeyTag is the tag used to select the cell labelled by a key in
+	// a vertical table when decoding a single struct.
+	selectorKeyTag = "key"
+	// pivotTag is the tag used to mark the key and value fields of the
+	// pivot columns of a table unpivoted into a row per column.
+	pivotTag = "pivot"
+	// selectorDialectTag is the tag used to name the dialect the field's
+	// selectors are written in, css by default or "xpath".
+	selectorDialectTag = "dialect"
 
 	// cSelInnerTextSelector is the selector used to extract text from a cell.
 	ctlInnerTextSelector = "text"
 	// cSelAttrSelector is the selector used to extract attributes from a cell.
 	ctlAttrSelector = "query"
+	// ctlURLSelector is the selector used to extract attributes from a cell
+	// as absolute urls.
+	ctlURLSelector = "url"
+	// ctlSpacesSelector is the selector used to extract text from a cell
+	// with runs of whitespace collapsed to single spaces.
+	ctlSpacesSelector = "spaces"
+	// ctlInnerHTMLSelector is the selector used to extract the inner html of
+	// a cell.
+	ctlInnerHTMLSelector = "html"
+	// ctlOuterHTMLSelector is the selector used to extract the outer html of
+	// a cell.
+	ctlOuterHTMLSelector = "outerhtml"
+	// ctlOwnTextSelector is the selector used to extract the text of a cell
+	// excluding the text of its descendant elements.
+	ctlOwnTextSelector = "owntext"
+	// ctlCountSelector is the selector used to count the elements matching
+	// the query selector in a cell.
+	ctlCountSelector = "count"
+	// ctlRegexSelector is the selector used to extract the text of a cell
+	// matching the regular expression of the query selector, or an empty
+	// value if it does not match.
+	ctlRegexSelector = "regex"
+
+	// defaultURLAttr is the attribute queried by the url control when no
+	// query selector is given.
+	defaultURLAttr = "href"
 )
 
 // SelectorConfig is a struct for configuring a selector
 type SelectorConfig struct {
-	DataSelector  string // selector for the data cell
-	HeadSelector  string // selector for the header cell
-	QuerySelector string // selector for the data cell
-	ControlTag    string // tag used to signify selecting aspects of a cell
+	DataSelector  string            // selector for the data cell
+	HeadSelector  string            // selector for the header cell
+	QuerySelector string            // selector for the data cell
+	ControlTag    string            // tag used to signify selecting aspects of a cell
+	Tag           reflect.StructTag // raw struct tag of the field
+	Follow        string            // link in the cell to follow and decode into the field
+	Transforms    string            // transforms applied to the selected value
+	Must          string            // raw text that must be present in the document
+	Required      bool              // whether the cell must not be empty
+	Pattern       string            // regular expression the cell must match
+	Min           string            // minimum number, or length for strings
+	Max           string            // maximum number, or length for strings
+	OneOf         string            // space separated values the cell must be one of
+	Locale        string            // locale numbers in the cell are written in
+	Map           string            // key=value entries mapping the cell onto values
+	Truthy        string            // space separated values of the cell that are true
+	Falsy         string            // space separated values of the cell that are false
+	Default       string            // value used when the cell is empty or missing
+	HasDefault    bool              // whether the field has a default tag
+	Fill          string            // how empty or missing cells are filled
+	Group         string            // selector for the group cells of the field
+	SubRow        string            // row of a struct with a stride the cells are in
+	Key           string            // label of the cell in a vertical table
+	Pivot         string            // whether the field is a pivot key or value
+	Dialect       string            // dialect the selectors are written in
 }
 
 // NewSelectorConfig parses a struct tag and returns a SelectorConfig
 func NewSelectorConfig(tag reflect.StructTag) *SelectorConfig {
 	cfg := &SelectorConfig{
+		Tag:           tag,
 		HeadSelector:  tag.Get(selectorHeaderTag),
 		DataSelector:  tag.Get(selectorDataTag),
 		QuerySelector: tag.Get(selectorQueryTag),
 		ControlTag:    tag.Get(selectorControlTag),
+		Follow:        tag.Get(selectorFollowTag),
+		Transforms:    tag.Get(transformTag),
+		Must:          tag.Get(validateMustTag),
+		Pattern:       tag.Get(validatePatternTag),
+		Min:           tag.Get(validateMinTag),
+		Max:           tag.Get(validateMaxTag),
+		OneOf:         tag.Get(validateOneOfTag),
+		Locale:        tag.Get(numberLocaleTag),
+		Map:           tag.Get(mapTag),
+		Truthy:        tag.Get(boolTruthyTag),
+		Falsy:         tag.Get(boolFalsyTag),
+		Fill:          tag.Get(fillTag),
+		Group:         tag.Get(selectorGroupTag),
+		SubRow:        tag.Get(selectorSubRowTag),
+		Key:           tag.Get(selectorKeyTag),
+		Pivot:         tag.Get(pivotTag),
+		Dialect:       tag.Get(selectorDialectTag),
+	}
+	cfg.HeadSelector = withDialect(cfg.Dialect, cfg.HeadSelector)
+	cfg.DataSelector = withDialect(cfg.Dialect, cfg.DataSelector)
+	cfg.Group = withDialect(cfg.Dialect, cfg.Group)
+	if cfg.ControlTag == ctlCountSelector {
+		cfg.QuerySelector = withDialect(cfg.Dialect, cfg.QuerySelector)
+	}
+	cfg.Default, cfg.HasDefault = tag.Lookup(defaultTag)
+	cfg.Required, _ = strconv.ParseBool(tag.Get(validateRequiredTag))
+	if cfg.ControlTag == ctlURLSelector && cfg.QuerySelector == "" {
+		cfg.QuerySelector = defaultURLAttr
 	}
-	if cfg.QuerySelector == "" || cfg.DataSelector == ctlAttrSelector {
+	_, registered := registeredControl(cfg.ControlTag)
+	if cfg.QuerySelector == "" && !queryless[cfg.ControlTag] && !registered ||
+		cfg.DataSelector == ctlAttrSelector {
 		cfg.QuerySelector, cfg.ControlTag =
 			ctlInnerTextSelector,
 			ctlInnerTextSelector
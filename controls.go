@@ -0,0 +1,75 @@
+package seltabl
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"sync"
+)
+
+var (
+	// controlsMu guards controls.
+	controlsMu sync.RWMutex
+	// controls are the control selectors registered with RegisterControl by
+	// name.
+	controls = make(map[string]func(cfg *SelectorConfig) SelectorI)
+)
+
+// RegisterControl makes a control selector available by the provided name
+// to the ctl tag of struct fields.
+//
+// The factory is called with the field's SelectorConfig, including its raw
+// struct tag, and returns the SelectorI used to select the value of each of
+// the field's cells.
+//
+// If RegisterControl is called twice with the same name, with the name of a
+// built in control or if the factory is nil, it panics.
+//
+// Example:
+//
+//	func init() {
+//		seltabl.RegisterControl("sprite", func(cfg *seltabl.SelectorConfig) seltabl.SelectorI {
+//			return spriteSelector{sheet: cfg.Tag.Get("sheet")}
+//		})
+//	}
+//
+//	type Row struct {
+//		Icon string `dSel:"tr td:nth-child(1)" ctl:"sprite" sheet:"teams"`
+//	}
+func RegisterControl(name string, factory func(cfg *SelectorConfig) SelectorI) {
+	controlsMu.Lock()
+	defer controlsMu.Unlock()
+	if factory == nil {
+		panic("seltabl: RegisterControl factory is nil")
+	}
+	if name == "" || slices.Contains(cSels, name) {
+		panic(fmt.Sprintf("seltabl: RegisterControl called with built in control %q", name))
+	}
+	if _, dup := controls[name]; dup {
+		panic(fmt.Sprintf("seltabl: RegisterControl called twice for control %q", name))
+	}
+	controls[name] = factory
+}
+
+// registeredControl returns the factory registered for the control, if any.
+func registeredControl(name string) (func(cfg *SelectorConfig) SelectorI, bool) {
+	controlsMu.RLock()
+	defer controlsMu.RUnlock()
+	factory, ok := controls[name]
+	return factory, ok
+}
+
+// newSelector returns the SelectorI for the given SelectorConfig.
+//
+// Registered controls are created with their factory, all other controls
+// are handled by the built in selector.
+func newSelector(cfg *SelectorConfig, base *url.URL) SelectorI {
+	if factory, ok := registeredControl(cfg.ControlTag); ok {
+		return factory(cfg)
+	}
+	return &selector{
+		control: cfg.ControlTag,
+		query:   cfg.QuerySelector,
+		base:    base,
+	}
+}
@@ -0,0 +1,87 @@
+package seltabl
+
+import (
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/stretchr/testify/assert"
+)
+
+// spriteSelector is a test control selecting the name of a sprite icon from
+// its css class.
+type spriteSelector struct {
+	prefix string
+}
+
+// Select implements SelectorI for spriteSelector.
+func (s spriteSelector) Select(cellValue *goquery.Selection) (string, error) {
+	class, _ := cellValue.Find("span").Attr("class")
+	for _, c := range strings.Fields(class) {
+		if strings.HasPrefix(c, s.prefix) {
+			return strings.TrimPrefix(c, s.prefix), nil
+		}
+	}
+	return "", nil
+}
+
+// countedSelectors counts the selectors created for the test-counted control.
+var countedSelectors atomic.Int32
+
+func init() {
+	RegisterControl("test-sprite", func(cfg *SelectorConfig) SelectorI {
+		return spriteSelector{prefix: cfg.Tag.Get("sprite")}
+	})
+	RegisterControl("test-counted", func(_ *SelectorConfig) SelectorI {
+		countedSelectors.Add(1)
+		return spriteSelector{prefix: "logo-"}
+	})
+}
+
+// TestRegisterControl tests decoding with a registered control.
+func TestRegisterControl(t *testing.T) {
+	t.Parallel()
+	type Team struct {
+		Name string `dSel:"tr td:nth-child(1)"`
+		Logo string `dSel:"tr td:nth-child(2)" ctl:"test-sprite" sprite:"logo-"`
+	}
+	result, err := NewFromString[Team](`
+		<table>
+			<tr><td>Ames</td><td><span class="icon logo-cyclones"></span></td></tr>
+			<tr><td>Iowa City</td><td><span class="icon logo-hawkeyes"></span></td></tr>
+		</table>`)
+	assert.NoError(t, err)
+	assert.Equal(t, []Team{
+		{Name: "Ames", Logo: "cyclones"},
+		{Name: "Iowa City", Logo: "hawkeyes"},
+	}, result)
+}
+
+// TestRegisterControl_OncePerField tests that the selector of a registered
+// control is created once per field rather than once per cell.
+func TestRegisterControl_OncePerField(t *testing.T) {
+	t.Parallel()
+	type Team struct {
+		Logo string `dSel:"tr td:nth-child(2)" ctl:"test-counted"`
+	}
+	result, err := NewFromString[Team](`
+		<table>
+			<tr><td>Ames</td><td><span class="logo-cyclones"></span></td></tr>
+			<tr><td>Iowa City</td><td><span class="logo-hawkeyes"></span></td></tr>
+			<tr><td>Lincoln</td><td><span class="logo-huskers"></span></td></tr>
+		</table>`)
+	assert.NoError(t, err)
+	assert.Len(t, result, 3)
+	assert.Equal(t, int32(1), countedSelectors.Load())
+}
+
+// TestRegisterControl_Panics tests that invalid registrations panic.
+func TestRegisterControl_Panics(t *testing.T) {
+	t.Parallel()
+	factory := func(_ *SelectorConfig) SelectorI { return spriteSelector{} }
+	assert.Panics(t, func() { RegisterControl("test-sprite", factory) })
+	assert.Panics(t, func() { RegisterControl(ctlInnerTextSelector, factory) })
+	assert.Panics(t, func() { RegisterControl("", factory) })
+	assert.Panics(t, func() { RegisterControl("test-nil", nil) })
+}
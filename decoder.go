@@ -84,3 +84,15 @@ func (d *Decoder[T]) Decode() ([]T, error) {
 	}
 	return result, nil
 }
+
+// DecodeOne parses a reader into a single struct.
+//
+// See NewOne for how fields are set.
+func (d *Decoder[T]) DecodeOne() (T, error) {
+	defer d.reader.Close()
+	result, err := NewOneFromReader[T](d.reader)
+	if err != nil {
+		return result, fmt.Errorf("failed to decode: %w", err)
+	}
+	return result, nil
+}
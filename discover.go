@@ -0,0 +1,265 @@
+package seltabl
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+const (
+	// discoverSamples is the number of rows sampled by Discover.
+	discoverSamples = 20
+)
+
+// TableInfo describes a table found in a document by Discover.
+type TableInfo struct {
+	// Selector is a selector matching only the table.
+	Selector string
+	// Caption is the text of the table's caption.
+	Caption string
+	// Heading is the text of the closest heading before the table.
+	Heading string
+	// Headers are the labels of the table's header row, if it has one.
+	Headers []string
+	// HeaderInHead is whether the header row is in the table's thead rather
+	// than being the first row of its body.
+	HeaderInHead bool
+	// Rows is the number of rows of the table, excluding the header row and
+	// any footer rows.
+	Rows int
+	// Columns is the number of columns of the table.
+	Columns int
+	// Samples are the cell texts of the first rows of the table.
+	Samples [][]string
+}
+
+// FieldInfo is a field of a struct proposed by Infer for a column.
+type FieldInfo struct {
+	// Name is the name of the field.
+	Name string
+	// Header is the label of the column.
+	Header string
+	// Type is the Go type of the field, such as "int" or "string".
+	Type string
+	// HeadSelector is the header selector (hSel) of the field.
+	HeadSelector string
+	// DataSelector is the data selector (dSel) of the field.
+	DataSelector string
+}
+
+// Tag returns the struct tag of the field.
+func (f FieldInfo) Tag() string {
+	parts := make([]string, 0, 2)
+	if f.HeadSelector != "" {
+		parts = append(parts, selectorHeaderTag+":"+strconv.Quote(f.HeadSelector))
+	}
+	parts = append(parts, selectorDataTag+":"+strconv.Quote(f.DataSelector))
+	return strings.Join(parts, " ")
+}
+
+// Discover describes every table in the document, excluding tables used
+// only to lay out a single cell.
+//
+// The header row of a table is the last row of its thead, or its first row
+// if every cell of it is a header cell.
+func Discover(doc *goquery.Document) []TableInfo {
+	order := documentOrder(doc)
+	headings := doc.Find("h1, h2, h3, h4, h5, h6")
+	tables := make([]TableInfo, 0)
+	doc.Find("table").Each(func(_ int, table *goquery.Selection) {
+		info := TableInfo{
+			Selector: tableSelector(doc, table),
+			Caption:  cellText(table.ChildrenFiltered("caption")),
+		}
+		headings.Each(func(_ int, heading *goquery.Selection) {
+			if order[heading.Get(0)] < order[table.Get(0)] {
+				info.Heading = cellText(heading)
+			}
+		})
+		body := table.ChildrenFiltered("tbody").ChildrenFiltered("tr")
+		header := table.ChildrenFiltered("thead").ChildrenFiltered("tr").Last()
+		info.HeaderInHead = header.Length() > 0
+		if !info.HeaderInHead {
+			first := body.First()
+			cells := first.Children()
+			if cells.Length() > 0 && cells.Length() == cells.Filter("th").Length() {
+				header = first
+				body = body.Slice(1, body.Length())
+			}
+		}
+		header.Children().Each(func(_ int, cell *goquery.Selection) {
+			info.Headers = append(info.Headers, cellText(cell))
+		})
+		info.Rows = body.Length()
+		info.Columns = len(info.Headers)
+		body.Each(func(j int, row *goquery.Selection) {
+			cells := row.Children()
+			info.Columns = max(info.Columns, cells.Length())
+			if j >= discoverSamples {
+				return
+			}
+			sample := make([]string, cells.Length())
+			cells.Each(func(k int, cell *goquery.Selection) {
+				sample[k] = cellText(cell)
+			})
+			info.Samples = append(info.Samples, sample)
+		})
+		if info.Rows == 0 || info.Columns < 2 && info.Rows < 2 {
+			return
+		}
+		tables = append(tables, info)
+	})
+	return tables
+}
+
+// Infer proposes a field for each column of a table found by Discover.
+//
+// Field names are derived from the header labels, and types from the sampled
+// cells: int or float64 if every non-empty cell is a number, bool if every
+// one is a yes or no value, and string otherwise.
+func Infer(table TableInfo) []FieldInfo {
+	fields := make([]FieldInfo, table.Columns)
+	names := make(map[string]bool, table.Columns)
+	for k := range fields {
+		header := ""
+		if k < len(table.Headers) {
+			header = table.Headers[k]
+		}
+		name := fieldName(header)
+		if name == "" || names[name] {
+			name = fmt.Sprintf("%sColumn%d", name, k+1)
+		}
+		names[name] = true
+		column := make([]string, 0, len(table.Samples))
+		for _, sample := range table.Samples {
+			if k < len(sample) {
+				column = append(column, sample[k])
+			}
+		}
+		fields[k] = FieldInfo{
+			Name:         name,
+			Header:       header,
+			Type:         inferType(column),
+			HeadSelector: headSelector(table, k),
+			DataSelector: dataSelector(table, k),
+		}
+	}
+	return fields
+}
+
+// headSelector returns the header selector of the kth column of the table.
+func headSelector(table TableInfo, k int) string {
+	switch {
+	case len(table.Headers) == 0:
+		return ""
+	case table.HeaderInHead:
+		return fmt.Sprintf("%s > thead > tr:last-child > :nth-child(%d)", table.Selector, k+1)
+	default:
+		return fmt.Sprintf("%s > tbody > tr:first-child > :nth-child(%d)", table.Selector, k+1)
+	}
+}
+
+// dataSelector returns the data selector of the kth column of the table.
+func dataSelector(table TableInfo, k int) string {
+	if len(table.Headers) > 0 && !table.HeaderInHead {
+		return fmt.Sprintf("%s > tbody > tr:not(:first-child) > :nth-child(%d)", table.Selector, k+1)
+	}
+	return fmt.Sprintf("%s > tbody > tr > :nth-child(%d)", table.Selector, k+1)
+}
+
+// tableSelector returns a selector matching only the table, preferring its
+// id, then its classes, then its path from the root of the document.
+func tableSelector(doc *goquery.Document, table *goquery.Selection) string {
+	if id, ok := table.Attr("id"); ok && id != "" {
+		sel := fmt.Sprintf("table[id=%s]", strconv.Quote(id))
+		if isIdentifier(id) {
+			sel = "table#" + id
+		}
+		if doc.Find(sel).Length() == 1 {
+			return sel
+		}
+	}
+	classes := strings.Fields(table.AttrOr("class", ""))
+	valid := len(classes) > 0
+	for _, class := range classes {
+		valid = valid && isIdentifier(class)
+	}
+	if valid {
+		sel := "table." + strings.Join(classes, ".")
+		if doc.Find(sel).Length() == 1 {
+			return sel
+		}
+	}
+	return cssPath(table)
+}
+
+// isIdentifier reports whether the name can be used unescaped in an id or
+// class selector.
+func isIdentifier(name string) bool {
+	for i, r := range name {
+		if !unicode.IsLetter(r) && r != '_' && r != '-' &&
+			(i == 0 || !unicode.IsDigit(r)) {
+			return false
+		}
+	}
+	return name != ""
+}
+
+// cellText returns the text of the selection with whitespace collapsed.
+func cellText(sel *goquery.Selection) string {
+	return strings.Join(strings.Fields(sel.Text()), " ")
+}
+
+// fieldName returns an exported Go identifier for the header label, such as
+// "GoalsAgainst" for "Goals against", or an empty string if it has no
+// letters or digits.
+func fieldName(header string) string {
+	var b strings.Builder
+	for _, word := range strings.FieldsFunc(header, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		r := []rune(word)
+		b.WriteString(string(unicode.ToUpper(r[0])) + string(r[1:]))
+	}
+	name := b.String()
+	if name == "" {
+		return ""
+	}
+	if r := []rune(name)[0]; !unicode.IsUpper(r) {
+		name = "Col" + name
+	}
+	return name
+}
+
+// inferType returns the Go type of a column from the values of its cells.
+func inferType(values []string) string {
+	ints, floats, bools, nonEmpty := true, true, true, 0
+	format := locales["en"]
+	for _, value := range values {
+		if value == "" {
+			continue
+		}
+		nonEmpty++
+		n, err := parseExactNumber(value, format)
+		number := err == nil
+		ints = ints && number && n.IsInt() && n.Num().IsInt64()
+		floats = floats && number
+		_, err = parseBool(&SelectorConfig{}, value)
+		bools = bools && !number && err == nil
+	}
+	switch {
+	case nonEmpty == 0:
+		return "string"
+	case ints:
+		return "int"
+	case floats:
+		return "float64"
+	case bools:
+		return "bool"
+	default:
+		return "string"
+	}
+}
@@ -0,0 +1,105 @@
+package seltabl
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/stretchr/testify/assert"
+)
+
+// discoverHTML is a document with several tables.
+const discoverHTML = `
+	<h1>Season</h1>
+	<table><tr><td>layout</td></tr></table>
+	<h2>Standings</h2>
+	<table class="standings wide">
+		<caption> Final  standings </caption>
+		<thead><tr> <th>Team</th> <th>Goals for</th> <th>Avg</th> <th>Won?</th> </tr></thead>
+		<tbody>
+			<tr> <td>Ames</td> <td>1,204</td> <td>1.5</td> <td>yes</td> </tr>
+			<tr> <td>Iowa</td> <td>17</td> <td>2</td> <td>no</td> </tr>
+		</tbody>
+	</table>
+	<table>
+		<tr> <th>Name</th> <th>Name</th> <th></th> </tr>
+		<tr> <td>Ada</td> <td>Bea</td> <td>x</td> </tr>
+	</table>`
+
+// TestDiscover tests describing the tables of a document.
+func TestDiscover(t *testing.T) {
+	t.Parallel()
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(discoverHTML))
+	if err != nil {
+		t.Fatalf("failed to create document: %v", err)
+	}
+	tables := Discover(doc)
+	if !assert.Len(t, tables, 2) {
+		return
+	}
+	assert.Equal(t, TableInfo{
+		Selector:     "table.standings.wide",
+		Caption:      "Final standings",
+		Heading:      "Standings",
+		Headers:      []string{"Team", "Goals for", "Avg", "Won?"},
+		HeaderInHead: true,
+		Rows:         2,
+		Columns:      4,
+		Samples: [][]string{
+			{"Ames", "1,204", "1.5", "yes"},
+			{"Iowa", "17", "2", "no"},
+		},
+	}, tables[0])
+	assert.Equal(t, "html > body > table:nth-child(5)", tables[1].Selector)
+	assert.Equal(t, 1, tables[1].Rows)
+	assert.Equal(t, 1, doc.Find(tables[1].Selector).Length())
+}
+
+// TestInfer tests decoding with the fields proposed for discovered tables.
+func TestInfer(t *testing.T) {
+	t.Parallel()
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(discoverHTML))
+	if err != nil {
+		t.Fatalf("failed to create document: %v", err)
+	}
+	tables := Discover(doc)
+	if !assert.Len(t, tables, 2) {
+		return
+	}
+
+	fields := Infer(tables[0])
+	names, types := make([]string, len(fields)), make([]string, len(fields))
+	schema := &Schema{}
+	for i, field := range fields {
+		names[i], types[i] = field.Name, field.Type
+		schema.Fields = append(schema.Fields, SchemaField{
+			Name:         field.Name,
+			Type:         field.Type,
+			DataSelector: field.DataSelector,
+			HeadSelector: field.HeadSelector,
+		})
+	}
+	assert.Equal(t, []string{"Team", "GoalsFor", "Avg", "Won"}, names)
+	assert.Equal(t, []string{"string", "int", "float64", "bool"}, types)
+	assert.Equal(t,
+		`hSel:"table.standings.wide > thead > tr:last-child > :nth-child(2)" `+
+			`dSel:"table.standings.wide > tbody > tr > :nth-child(2)"`,
+		fields[1].Tag(),
+	)
+	rows, err := schema.Decode(doc)
+	assert.NoError(t, err)
+	assert.Equal(t, []Row{
+		{"Team": "Ames", "GoalsFor": 1204, "Avg": 1.5, "Won": true},
+		{"Team": "Iowa", "GoalsFor": 17, "Avg": 2.0, "Won": false},
+	}, rows)
+
+	fields = Infer(tables[1])
+	assert.Len(t, fields, 3)
+	assert.Equal(t, "Name", fields[0].Name)
+	assert.Equal(t, "NameColumn2", fields[1].Name)
+	assert.Equal(t, "Column3", fields[2].Name)
+	assert.Equal(t,
+		"html > body > table:nth-child(5) > tbody > tr:not(:first-child) > :nth-child(1)",
+		fields[0].DataSelector,
+	)
+}
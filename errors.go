@@ -1,10 +1,15 @@
 package seltabl
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 )
 
+// errNoDataFound is returned when decoding a document yields no rows.
+var errNoDataFound = errors.New("no data found")
+
 // ErrNoDataFound is an error for when no data is found for a selector
 type ErrNoDataFound struct {
 	Typ   reflect.Type
@@ -23,6 +28,22 @@ func (e ErrNoDataFound) Error() string {
 	)
 }
 
+// ErrStatus is returned when fetching a page responds with a non-2xx status
+// code.
+type ErrStatus struct {
+	URL        string // url of the page
+	StatusCode int    // status code of the response
+}
+
+// Error implements the error interface for ErrStatus
+func (e ErrStatus) Error() string {
+	return fmt.Sprintf(
+		"received non-2xx response code %d from %s",
+		e.StatusCode,
+		e.URL,
+	)
+}
+
 // ErrSelectorNotFound is an error for when a selector is not found
 type ErrSelectorNotFound struct {
 	Typ   reflect.Type        // type of the struct
@@ -58,3 +79,53 @@ func (e ErrParsing) Error() string {
 		e.Err,
 	)
 }
+
+// ErrValidation is returned when a value fails a validation tag.
+type ErrValidation struct {
+	Row   int                 // index of the row, -1 for the document
+	Field reflect.StructField // field of the struct
+	Tag   string              // validation tag that failed
+	Rule  string              // value of the validation tag
+	Value string              // value that failed validation
+}
+
+// Error implements the error interface for ErrValidation
+func (e ErrValidation) Error() string {
+	if e.Row < 0 {
+		return fmt.Sprintf(
+			"field %s: document fails %s:%q",
+			e.Field.Name,
+			e.Tag,
+			e.Rule,
+		)
+	}
+	return fmt.Sprintf(
+		"row %d field %s: value %q fails %s:%q",
+		e.Row,
+		e.Field.Name,
+		e.Value,
+		e.Tag,
+		e.Rule,
+	)
+}
+
+// ErrMisaligned is returned when the fields of a struct select different
+// numbers of cells, so the cells of each row cannot be matched up by index.
+type ErrMisaligned struct {
+	Typ    reflect.Type          // type of the struct
+	Fields []reflect.StructField // fields of the struct
+	Counts []int                 // number of cells selected for each field
+}
+
+// Error implements the error interface for ErrMisaligned
+func (e ErrMisaligned) Error() string {
+	counts := make([]string, len(e.Fields))
+	for i, field := range e.Fields {
+		counts[i] = fmt.Sprintf("%s=%d", field.Name, e.Counts[i])
+	}
+	return fmt.Sprintf(
+		"fields of %s select different numbers of cells: %s",
+		e.Typ,
+		strings.Join(counts, ", "),
+	)
+}
@@ -0,0 +1,48 @@
+package seltabl
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// fetchDocument gets the html of the given url and parses it into a goquery
+// document.
+//
+// It is used by the NewFromURL functions to share the fetching of pages.
+//
+// The returned document's Url is set to the final url of the response so that
+// relative links found in the page can be resolved against it. Responses with
+// a non-2xx status code return an ErrStatus.
+func fetchDocument(
+	ctx context.Context,
+	client *http.Client,
+	url string,
+) (*goquery.Document, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get url: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, ErrStatus{URL: url, StatusCode: resp.StatusCode}
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read body: %w", err)
+	}
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse html: %w", err)
+	}
+	doc.Url = resp.Request.URL
+	return doc, nil
+}
@@ -0,0 +1,244 @@
+package seltabl
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+const (
+	// followAttrSeparator separates the selector from the attribute in a
+	// follow tag.
+	followAttrSeparator = "@"
+	// defaultFollowAttr is the attribute followed when a follow tag does not
+	// name one.
+	defaultFollowAttr = "href"
+)
+
+// follower fetches linked pages for fields with a follow tag.
+//
+// It limits the number of pages fetched at once and caches fetched pages so
+// that repeated links are only fetched once.
+type follower struct {
+	client *http.Client
+	sem    chan struct{}
+	mu     sync.Mutex
+	pages  map[string]*followedPage
+}
+
+// followedPage is a page fetched by a follower.
+type followedPage struct {
+	done      chan struct{}
+	doc       *goquery.Document
+	fetchedAt time.Time
+	err       error
+}
+
+// newFollower creates a new follower fetching at most limit pages at once.
+func newFollower(client *http.Client, limit int) *follower {
+	return &follower{
+		client: client,
+		sem:    make(chan struct{}, limit),
+		pages:  make(map[string]*followedPage),
+	}
+}
+
+// fetch gets the page at the given url.
+//
+// Concurrent calls for the same url share a single request.
+func (f *follower) fetch(ctx context.Context, url string) (*followedPage, error) {
+	f.mu.Lock()
+	page, ok := f.pages[url]
+	if !ok {
+		page = &followedPage{done: make(chan struct{})}
+		f.pages[url] = page
+	}
+	f.mu.Unlock()
+	if ok {
+		<-page.done
+		return page, page.err
+	}
+	defer close(page.done)
+	select {
+	case f.sem <- struct{}{}:
+		defer func() { <-f.sem }()
+	case <-ctx.Done():
+		page.err = ctx.Err()
+		return nil, page.err
+	}
+	page.doc, page.err = fetchDocument(ctx, f.client, url)
+	page.fetchedAt = time.Now()
+	return page, page.err
+}
+
+// fetchAll gets the pages at the given urls concurrently.
+func (f *follower) fetchAll(
+	ctx context.Context,
+	urls []string,
+) (map[string]*followedPage, error) {
+	pages := make(map[string]*followedPage, len(urls))
+	errs := make([]error, len(urls))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for i, u := range urls {
+		wg.Add(1)
+		go func(i int, u string) {
+			defer wg.Done()
+			page, err := f.fetch(ctx, u)
+			if err != nil {
+				errs[i] = fmt.Errorf("failed to follow %s: %w", u, err)
+				return
+			}
+			mu.Lock()
+			pages[u] = page
+			mu.Unlock()
+		}(i, u)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return pages, nil
+}
+
+// followLink returns the absolute url of the link selected from the cell by
+// the given follow tag.
+//
+// A follow tag is a selector and an attribute separated by an @, for example
+// "a@href". The selector is run relative to the cell and defaults to the cell
+// itself, the attribute defaults to href.
+//
+// An empty string is returned if the cell does not contain a link.
+func followLink(
+	cell *goquery.Selection,
+	follow string,
+	base *url.URL,
+) (string, error) {
+	sel, attr, _ := strings.Cut(follow, followAttrSeparator)
+	if attr == "" {
+		attr = defaultFollowAttr
+	}
+	link := cell
+	if sel != "" {
+		link = cell.Find(sel)
+	}
+	href, exists := link.First().Attr(attr)
+	href = strings.TrimSpace(href)
+	if !exists || href == "" {
+		return "", nil
+	}
+	u, err := url.Parse(href)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse followed url %s: %w", href, err)
+	}
+	if base != nil {
+		u = base.ResolveReference(u)
+	}
+	return u.String(), nil
+}
+
+// followField fills the given field of each row by following the link in
+// the row's cell and decoding the linked page into the field's type.
+//
+// Struct and pointer to struct fields are set to the first row decoded from
+// the linked page, slice fields are set to every row. Fields are left with
+// their zero value if the linked page has no rows or its selectors match
+// nothing, or if it is already being decoded by an enclosing followed page,
+// as with links back to it.
+func followField(
+	base *url.URL,
+	results reflect.Value,
+	field reflect.StructField,
+	cells *goquery.Selection,
+	positions []int,
+	cfg *SelectorConfig,
+	o *options,
+) error {
+	links := make([]string, cells.Length())
+	unique := make([]string, 0, len(links))
+	seen := make(map[string]bool)
+	for j := range links {
+		link, err := followLink(cells.Eq(j), cfg.Follow, base)
+		if err != nil {
+			return err
+		}
+		if o.following[link] {
+			continue
+		}
+		links[j] = link
+		if link != "" && !seen[link] {
+			seen[link] = true
+			unique = append(unique, link)
+		}
+	}
+	pages, err := o.follower.fetchAll(o.ctx, unique)
+	if err != nil {
+		return err
+	}
+	elemType := followedType(field.Type)
+	decoded := make(map[string]reflect.Value, len(pages))
+	for link, page := range pages {
+		rows, err := decode(page.doc, elemType, o.forFollowed(link, page.fetchedAt))
+		if isExhausted(err) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to decode %s: %w", link, err)
+		}
+		decoded[link] = rows
+	}
+	for j, link := range links {
+		rows, ok := decoded[link]
+		if !ok {
+			continue
+		}
+		value := results.Index(positions[j]).FieldByIndex(field.Index)
+		setFollowed(value, rows)
+	}
+	return nil
+}
+
+// followedType returns the struct type decoded for a field with a follow
+// tag.
+func followedType(typ reflect.Type) reflect.Type {
+	if typ.Kind() == reflect.Slice {
+		typ = typ.Elem()
+	}
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	return typ
+}
+
+// setFollowed sets the field to the rows decoded from a followed page.
+func setFollowed(field reflect.Value, rows reflect.Value) {
+	if field.Kind() != reflect.Slice {
+		setFollowedRow(field, rows.Index(0))
+		return
+	}
+	slice := reflect.MakeSlice(field.Type(), rows.Len(), rows.Len())
+	for i := 0; i < rows.Len(); i++ {
+		setFollowedRow(slice.Index(i), rows.Index(i))
+	}
+	field.Set(slice)
+}
+
+// setFollowedRow sets the struct or pointer to struct value to the row.
+func setFollowedRow(value reflect.Value, row reflect.Value) {
+	if value.Kind() != reflect.Ptr {
+		value.Set(row)
+		return
+	}
+	ptr := reflect.New(value.Type().Elem())
+	ptr.Elem().Set(row)
+	value.Set(ptr)
+}
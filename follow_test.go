@@ -0,0 +1,190 @@
+package seltabl
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// PlayerDetail is a test struct decoded from a followed player page.
+type PlayerDetail struct {
+	Position string `dSel:"table tr td.position"`
+	Number   int    `dSel:"table tr td.number"`
+}
+
+// Player is a test struct following links to player pages.
+type Player struct {
+	Name    string       `dSel:"table tr td.name"`
+	Profile PlayerDetail `dSel:"table tr td.name" follow:"a@href"`
+}
+
+// PlayerRefs is a test struct following links into pointer and slice
+// fields.
+type PlayerRefs struct {
+	Name     string         `dSel:"table tr td.name"`
+	Profile  *PlayerDetail  `dSel:"table tr td.name" follow:"a"`
+	Profiles []PlayerDetail `dSel:"table tr td.name a" follow:"@href"`
+}
+
+// newRosterServer creates a test server serving a roster linking to player
+// pages and counts the requests made for each player page.
+func newRosterServer(t *testing.T, delay time.Duration) (*httptest.Server, *sync.Map, *int32) {
+	t.Helper()
+	var hits sync.Map
+	var inFlight, maxInFlight int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/roster", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintln(w, `
+			<table>
+				<tr><td class="name"><a href="/players/1">Ann</a></td></tr>
+				<tr><td class="name"><a href="players/2">Bob</a></td></tr>
+				<tr><td class="name"><a href="/players/1">Ann</a></td></tr>
+				<tr><td class="name"><a href="/players/3">Cal</a></td></tr>
+			</table>`)
+	})
+	mux.HandleFunc("/players/", func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			seen := atomic.LoadInt32(&maxInFlight)
+			if current <= seen || atomic.CompareAndSwapInt32(&maxInFlight, seen, current) {
+				break
+			}
+		}
+		time.Sleep(delay)
+		count, _ := hits.LoadOrStore(r.URL.Path, new(int32))
+		atomic.AddInt32(count.(*int32), 1)
+		id := r.URL.Path[len("/players/"):]
+		fmt.Fprintf(w, `
+			<table>
+				<tr><td class="position">P%s</td><td class="number">%s0</td></tr>
+			</table>`, id, id)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server, &hits, &maxInFlight
+}
+
+// TestNewFromURL_Follow tests that links are followed and decoded into
+// nested structs.
+func TestNewFromURL_Follow(t *testing.T) {
+	t.Parallel()
+	server, hits, _ := newRosterServer(t, 0)
+	result, err := NewFromURL[Player](server.URL + "/roster")
+	assert.NoError(t, err)
+	assert.Equal(t, []Player{
+		{Name: "Ann", Profile: PlayerDetail{Position: "P1", Number: 10}},
+		{Name: "Bob", Profile: PlayerDetail{Position: "P2", Number: 20}},
+		{Name: "Ann", Profile: PlayerDetail{Position: "P1", Number: 10}},
+		{Name: "Cal", Profile: PlayerDetail{Position: "P3", Number: 30}},
+	}, result)
+	count, ok := hits.Load("/players/1")
+	assert.True(t, ok)
+	assert.Equal(t, int32(1), *count.(*int32))
+}
+
+// TestNewFromURL_FollowPointerAndSlice tests following links into pointer
+// and slice fields.
+func TestNewFromURL_FollowPointerAndSlice(t *testing.T) {
+	t.Parallel()
+	server, _, _ := newRosterServer(t, 0)
+	result, err := NewFromURL[PlayerRefs](server.URL + "/roster")
+	assert.NoError(t, err)
+	assert.Len(t, result, 4)
+	assert.Equal(t, &PlayerDetail{Position: "P2", Number: 20}, result[1].Profile)
+	assert.Equal(t, []PlayerDetail{{Position: "P3", Number: 30}}, result[3].Profiles)
+}
+
+// TestNewFromURL_FollowLimit tests that the follow limit bounds the number
+// of pages fetched at once.
+func TestNewFromURL_FollowLimit(t *testing.T) {
+	t.Parallel()
+	server, _, maxInFlight := newRosterServer(t, 20*time.Millisecond)
+	_, err := NewFromURL[Player](server.URL+"/roster", WithFollowLimit(1))
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(maxInFlight))
+}
+
+// TestNewFromString_FollowMissingLink tests that rows without a link are
+// left with a zero value.
+func TestNewFromString_FollowMissingLink(t *testing.T) {
+	t.Parallel()
+	result, err := NewFromString[Player](`
+		<table>
+			<tr><td class="name">Ann</td></tr>
+		</table>`)
+	assert.NoError(t, err)
+	assert.Equal(t, []Player{{Name: "Ann"}}, result)
+}
+
+// TestNewFromURL_FollowNoRows tests that links to pages without rows are
+// left with a zero value.
+func TestNewFromURL_FollowNoRows(t *testing.T) {
+	t.Parallel()
+	type Stats struct {
+		Goals int `dSel:"table tr td.goals"`
+	}
+	type Scorer struct {
+		Name  string `dSel:"table tr td.name"`
+		Stats *Stats `dSel:"table tr td.name" follow:"a"`
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/scorers", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintln(w, `
+			<table>
+				<tr><td class="name"><a href="/stats/1">Ann</a></td></tr>
+				<tr><td class="name"><a href="/stats/2">Bob</a></td></tr>
+			</table>`)
+	})
+	mux.HandleFunc("/stats/1", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintln(w, `<table><tr><td class="goals">3</td></tr></table>`)
+	})
+	mux.HandleFunc("/stats/2", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintln(w, `<p>no stats yet</p>`)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	result, err := NewFromURL[Scorer](server.URL + "/scorers")
+	assert.NoError(t, err)
+	assert.Equal(t, []Scorer{
+		{Name: "Ann", Stats: &Stats{Goals: 3}},
+		{Name: "Bob"},
+	}, result)
+}
+
+// LinkedPage is a test struct following links to pages of its own type.
+type LinkedPage struct {
+	Title string      `dSel:"h1"`
+	Next  *LinkedPage `dSel:"h1" follow:"a"`
+}
+
+// TestNewFromURL_FollowCycle tests that links back to a page being followed
+// are not followed again.
+func TestNewFromURL_FollowCycle(t *testing.T) {
+	t.Parallel()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/a", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintln(w, `<h1><a href="/b">A</a></h1>`)
+	})
+	mux.HandleFunc("/b", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintln(w, `<h1><a href="/a">B</a></h1>`)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	result, err := NewFromURL[LinkedPage](server.URL + "/a")
+	assert.NoError(t, err)
+	if !assert.Len(t, result, 1) || !assert.NotNil(t, result[0].Next) {
+		return
+	}
+	assert.Equal(t, "B", result[0].Next.Title)
+	if assert.NotNil(t, result[0].Next.Next) {
+		assert.Equal(t, "A", result[0].Next.Next.Title)
+		assert.Nil(t, result[0].Next.Next.Next)
+	}
+}
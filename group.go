@@ -0,0 +1,128 @@
+package seltabl
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+)
+
+const (
+	// fillDown is the value of a fill tag filling empty cells with the
+	// value of the cell above.
+	fillDown = "down"
+)
+
+// hasFillDown reports whether any field of the struct type fills down.
+func hasFillDown(dType reflect.Type) bool {
+	for i := 0; i < dType.NumField(); i++ {
+		if dType.Field(i).Tag.Get(fillTag) == fillDown {
+			return true
+		}
+	}
+	return false
+}
+
+// hasGroups reports whether any field of the struct type has a group tag.
+func hasGroups(dType reflect.Type) bool {
+	for i := 0; i < dType.NumField(); i++ {
+		if dType.Field(i).Tag.Get(selectorGroupTag) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// setGroups sets the fields with a group tag of each row to the value
+// selected from the last group cell before the row in document order.
+//
+// Rows before the first group cell are left unset, or set to the field's
+// default.
+func setGroups(
+	doc *goquery.Document,
+	results reflect.Value,
+	rows []*goquery.Selection,
+	order map[*html.Node]int,
+	base *url.URL,
+) error {
+	dType := results.Type().Elem()
+	for i := 0; i < dType.NumField(); i++ {
+		field := dType.Field(i)
+		cfg := NewSelectorConfig(field.Tag)
+		if cfg.Group == "" {
+			continue
+		}
+		groups := find(doc.Selection, cfg.Group)
+		selector := newSelector(cfg, base)
+		starts := make([]int, groups.Length())
+		for k := range starts {
+			starts[k] = order[groups.Get(k)]
+		}
+		for j, row := range rows {
+			start := order[rowNode(row)]
+			k := sort.Search(len(starts), func(k int) bool {
+				return starts[k] > start
+			}) - 1
+			if k >= 0 {
+				err := setCell(results, j, field, groups.Eq(k), cfg, selector)
+				if err != nil {
+					return err
+				}
+				continue
+			}
+			if cfg.HasDefault {
+				value := results.Index(j).Field(i)
+				err := setFieldValue(value.Kind(), cfg.Default, &value, cfg)
+				if err != nil {
+					return fmt.Errorf(
+						"failed to set default of field %s: %w",
+						field.Name,
+						err,
+					)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// fillDownField sets the field of each row to the value selected from its
+// cell, or from the closest non-empty cell above it if its cell is empty or
+// missing.
+func fillDownField(
+	results reflect.Value,
+	m *fieldMatch,
+	base *url.URL,
+) error {
+	field := results.Type().Elem().Field(m.index)
+	cells := make([]*goquery.Selection, results.Len())
+	for j := 0; j < m.cells.Length(); j++ {
+		cells[m.positions[j]] = m.cells.Eq(j)
+	}
+	selector := newSelector(m.cfg, base)
+	var last *goquery.Selection
+	for j, cell := range cells {
+		if cell != nil {
+			value, err := selector.Select(cell)
+			if err == nil && strings.TrimSpace(value) != "" {
+				last = cell
+			} else if last != nil {
+				cell = last
+			}
+		} else {
+			cell = last
+		}
+		if cell == nil {
+			continue
+		}
+		err := setCell(results, j, field, cell, m.cfg, selector)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
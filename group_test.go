@@ -0,0 +1,109 @@
+package seltabl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// groupHTML is a table with conference header rows and division cells
+// left empty below the first team of each division.
+const groupHTML = `
+	<table>
+		<tr class="conference"> <th colspan="3">Big Ten</th> </tr>
+		<tr> <td>East</td> <td>Ohio</td> <td>11</td> </tr>
+		<tr> <td></td> <td>Penn</td> <td>10</td> </tr>
+		<tr> <td>West</td> <td>Iowa</td> <td>9</td> </tr>
+		<tr class="conference"> <th colspan="3">Big 12</th> </tr>
+		<tr> <td>North</td> <td>Ames</td> <td>7</td> </tr>
+		<tr> <td> </td> <td>Kansas</td> <td>5</td> </tr>
+	</table>`
+
+// TestNewFromString_Group tests the group and fill tags.
+func TestNewFromString_Group(t *testing.T) {
+	t.Parallel()
+	type Standing struct {
+		_          struct{} `skipSel:"tr.conference"`
+		Conference string   `group:"tr.conference th"`
+		Division   string   `dSel:"tr td:nth-child(1)" fill:"down"`
+		Team       string   `dSel:"tr td:nth-last-child(2)"`
+		Wins       int      `dSel:"tr td:last-child"`
+	}
+	result, err := NewFromString[Standing](groupHTML)
+	assert.NoError(t, err)
+	assert.Equal(t, []Standing{
+		{Conference: "Big Ten", Division: "East", Team: "Ohio", Wins: 11},
+		{Conference: "Big Ten", Division: "East", Team: "Penn", Wins: 10},
+		{Conference: "Big Ten", Division: "West", Team: "Iowa", Wins: 9},
+		{Conference: "Big 12", Division: "North", Team: "Ames", Wins: 7},
+		{Conference: "Big 12", Division: "North", Team: "Kansas", Wins: 5},
+	}, result)
+
+	type Tbody struct {
+		Group string `group:"tbody" ctl:"query" qSel:"data-group"`
+		Team  string `dSel:"tbody td"`
+	}
+	tbody, err := NewFromString[Tbody](`
+		<table>
+			<tbody data-group="a"> <tr><td>Ohio</td></tr> <tr><td>Penn</td></tr> </tbody>
+			<tbody data-group="b"> <tr><td>Ames</td></tr> </tbody>
+		</table>`)
+	assert.NoError(t, err)
+	assert.Equal(t, []Tbody{
+		{Group: "a", Team: "Ohio"},
+		{Group: "a", Team: "Penn"},
+		{Group: "b", Team: "Ames"},
+	}, tbody)
+
+	type Before struct {
+		Group string `group:"tr.group td" default:"none"`
+		Team  string `dSel:"tr:not(.group) td"`
+	}
+	before, err := NewFromString[Before](`
+		<table>
+			<tr><td>Ohio</td></tr>
+			<tr class="group"><td>x</td></tr>
+			<tr><td>Ames</td></tr>
+		</table>`)
+	assert.NoError(t, err)
+	assert.Equal(t, []Before{
+		{Group: "none", Team: "Ohio"},
+		{Group: "x", Team: "Ames"},
+	}, before)
+
+	type XPath struct {
+		_          struct{} `skipSel:"tr.conference"`
+		Conference string   `group:"xpath://tr[@class='conference']/th"`
+		Division   string   `group:"td.division || tr.conference th"`
+		Team       string   `dSel:"tr td:nth-last-child(2)"`
+	}
+	xpath, err := NewFromString[XPath](groupHTML, WithAlignment(AlignByRow))
+	assert.NoError(t, err)
+	if assert.Len(t, xpath, 5) {
+		assert.Equal(t, XPath{Conference: "Big Ten", Division: "Big Ten", Team: "Ohio"}, xpath[0])
+		assert.Equal(t, XPath{Conference: "Big 12", Division: "Big 12", Team: "Kansas"}, xpath[4])
+	}
+
+	type Rowspan struct {
+		Team string `dSel:"tr td.team" fill:"down"`
+		Week int    `dSel:"tr td.week"`
+	}
+	rowspan, err := NewFromString[Rowspan](`
+		<table>
+			<tr> <td class="team" rowspan="2">A</td> <td class="week">1</td> </tr>
+			<tr> <td class="week">2</td> </tr>
+			<tr> <td class="team">B</td> <td class="week">3</td> </tr>
+		</table>`)
+	assert.NoError(t, err)
+	assert.Equal(t, []Rowspan{
+		{Team: "A", Week: 1},
+		{Team: "A", Week: 2},
+		{Team: "B", Week: 3},
+	}, rowspan)
+
+	type Unsupported struct {
+		Team string `dSel:"tr td" fill:"up"`
+	}
+	_, err = NewFromString[Unsupported](groupHTML)
+	assert.ErrorContains(t, err, "unsupported fill up")
+}
@@ -0,0 +1,131 @@
+package seltabl
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var (
+	// mappings caches parsed map tags by their value.
+	mappings sync.Map
+
+	// defaultTruthy are the values of a bool field that are true when the
+	// field has no true tag.
+	defaultTruthy = []string{"true", "yes", "y", "on", "1", "✓", "✔", "☑"}
+	// defaultFalsy are the values of a bool field that are false when the
+	// field has no false tag.
+	defaultFalsy = []string{"false", "no", "n", "off", "0", "✗", "✘", "☐"}
+
+	// errNotABool is returned when a value is neither truthy nor falsy.
+	errNotABool = errors.New("not a boolean")
+)
+
+// applyMapping maps the value through the entries of a map tag.
+//
+// A map tag is a comma separated list of key=value entries. Keys and values
+// containing commas or equals signs can be single quoted. Empty values that
+// are not a key are left empty; any other value that is not a key fails with
+// an ErrValidation.
+//
+// Example:
+//
+//	map:"W=win,L=loss,T=tie"
+func applyMapping(
+	cfg *SelectorConfig,
+	structField reflect.StructField,
+	value string,
+) (string, error) {
+	if cfg.Map == "" {
+		return value, nil
+	}
+	mapping, err := parseMapping(cfg.Map)
+	if err != nil {
+		return "", err
+	}
+	if mapped, ok := mapping[value]; ok {
+		return mapped, nil
+	}
+	if value == "" {
+		return value, nil
+	}
+	return "", ErrValidation{
+		Field: structField,
+		Tag:   mapTag,
+		Rule:  cfg.Map,
+		Value: value,
+	}
+}
+
+// parseMapping parses a map tag into its entries, caching the result.
+func parseMapping(spec string) (map[string]string, error) {
+	if mapping, ok := mappings.Load(spec); ok {
+		return mapping.(map[string]string), nil
+	}
+	entries, err := splitTopLevel(spec)
+	if err != nil {
+		return nil, err
+	}
+	mapping := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		key, value, ok := cutUnquoted(entry, '=')
+		if !ok {
+			return nil, fmt.Errorf("map entry %s is not of the form key=value", entry)
+		}
+		mapping[unquote(strings.TrimSpace(key))] = unquote(strings.TrimSpace(value))
+	}
+	mappings.Store(spec, mapping)
+	return mapping, nil
+}
+
+// cutUnquoted slices s around the first sep that is not in single quotes.
+func cutUnquoted(s string, sep rune) (before, after string, found bool) {
+	quoted := false
+	for i, r := range s {
+		switch {
+		case r == '\'':
+			quoted = !quoted
+		case r == sep && !quoted:
+			return s[:i], s[i+1:], true
+		}
+	}
+	return s, "", false
+}
+
+// parseBool parses the value of a bool field.
+//
+// The value is compared case insensitively against the space separated
+// values of the field's true and false tags, or against defaultTruthy and
+// defaultFalsy. Otherwise a whole number is true when it is not zero, so
+// the count control can be used to test for the presence of an element. An
+// empty value is false.
+func parseBool(cfg *SelectorConfig, value string) (bool, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return false, nil
+	}
+	truthy, falsy := defaultTruthy, defaultFalsy
+	if cfg.Truthy != "" {
+		truthy = strings.Fields(cfg.Truthy)
+	}
+	if cfg.Falsy != "" {
+		falsy = strings.Fields(cfg.Falsy)
+	}
+	for _, t := range truthy {
+		if strings.EqualFold(value, t) {
+			return true, nil
+		}
+	}
+	for _, f := range falsy {
+		if strings.EqualFold(value, f) {
+			return false, nil
+		}
+	}
+	if n, err := strconv.Atoi(value); err == nil {
+		return n != 0, nil
+	}
+	return false, errNotABool
+}
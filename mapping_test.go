@@ -0,0 +1,92 @@
+package seltabl
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// resultsHTML is the html decoded by the mapping tests.
+const resultsHTML = `
+	<table>
+		<tr> <td>Team</td> <td>Result</td> <td>Home</td> <td>Ranked</td> <td>Bowl</td> </tr>
+		<tr> <td>Ames</td> <td>W</td> <td>✓</td> <td>Y</td> <td><img class="bowl"/></td> </tr>
+		<tr> <td>Iowa</td> <td>L</td> <td>✗</td> <td>N</td> <td></td> </tr>
+		<tr> <td>Ohio</td> <td>T</td> <td></td> <td>Y</td> <td></td> </tr>
+	</table>`
+
+// TestNewFromString_Mapping tests decoding bool fields and the map tag.
+func TestNewFromString_Mapping(t *testing.T) {
+	t.Parallel()
+	type Game struct {
+		Team   string `dSel:"tr:not(:first-child) td:nth-child(1)"`
+		Result string `dSel:"tr:not(:first-child) td:nth-child(2)" map:"W=win,L=loss,T=tie"`
+		Points int    `dSel:"tr:not(:first-child) td:nth-child(2)" map:"W=3,L=0,T=1"`
+		Home   bool   `dSel:"tr:not(:first-child) td:nth-child(3)"`
+		Ranked bool   `dSel:"tr:not(:first-child) td:nth-child(4)" true:"Y" false:"N"`
+		Bowl   bool   `dSel:"tr:not(:first-child) td:nth-child(5)" ctl:"count" qSel:"img.bowl"`
+	}
+	result, err := NewFromString[Game](resultsHTML)
+	assert.NoError(t, err)
+	assert.Equal(t, []Game{
+		{Team: "Ames", Result: "win", Points: 3, Home: true, Ranked: true, Bowl: true},
+		{Team: "Iowa", Result: "loss", Points: 0, Home: false, Ranked: false},
+		{Team: "Ohio", Result: "tie", Points: 1, Home: false, Ranked: true},
+	}, result)
+
+	type Unknown struct {
+		Result string `dSel:"tr:not(:first-child) td:nth-child(2)" map:"W=win,L=loss"`
+	}
+	_, err = NewFromString[Unknown](resultsHTML)
+	var validationErr ErrValidation
+	assert.True(t, errors.As(err, &validationErr), "expected ErrValidation, got %v", err)
+	assert.Equal(t, 2, validationErr.Row)
+	assert.Equal(t, "map", validationErr.Tag)
+	assert.Equal(t, "T", validationErr.Value)
+
+	type NotBool struct {
+		Ranked bool `dSel:"tr:not(:first-child) td:nth-child(4)" true:"yes"`
+	}
+	_, err = NewFromString[NotBool](resultsHTML)
+	assert.ErrorContains(t, err, "not a boolean")
+}
+
+// TestParseMapping tests parsing map tags.
+func TestParseMapping(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		spec    string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name: "entries",
+			spec: "W=win, L = loss",
+			want: map[string]string{"W": "win", "L": "loss"},
+		},
+		{
+			name: "quoted",
+			spec: "'a=b'=equals,'1,000'=thousand,=empty",
+			want: map[string]string{"a=b": "equals", "1,000": "thousand", "": "empty"},
+		},
+		{
+			name:    "missing value",
+			spec:    "W=win,L",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseMapping(tt.spec)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package seltabl
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Meta is the provenance of a decoded row.
+//
+// A struct field of type Meta is filled by New with where the row was
+// decoded from instead of being selected from a cell.
+//
+// Example:
+//
+//	type Row struct {
+//		Name string        `dSel:"tr td:nth-child(1)"`
+//		Meta seltabl.Meta
+//	}
+type Meta struct {
+	// Index is the index of the row in the decoded slice.
+	Index int
+	// URL is the url of the document the row was decoded from.
+	URL string
+	// FetchedAt is when the document was fetched. It is zero for documents
+	// that were not fetched from a url.
+	FetchedAt time.Time
+	// Path is a css selector path uniquely matching the row element.
+	Path string
+	// HTML is the outer html of the row element.
+	HTML string
+	// Selectors are the data selectors chosen from the selector chains of
+	// the fields, keyed by field name. It is nil if no field has a chain.
+	Selectors map[string]string
+}
+
+// metaType is the reflect.Type of Meta.
+var metaType = reflect.TypeOf(Meta{})
+
+// rowElement returns the element of the row containing the cell.
+//
+// It is the closest table row of the cell, or the parent of the cell if the
+// cell is not in a table row.
+func rowElement(cell *goquery.Selection) *goquery.Selection {
+	row := cell.First().Closest("tr")
+	if row.Length() > 0 {
+		return row
+	}
+	return cell.First().Parent()
+}
+
+// setMeta fills the Meta fields of each row.
+func setMeta(
+	results reflect.Value,
+	rows []*goquery.Selection,
+	url string,
+	fetchedAt time.Time,
+	selectors map[string]string,
+) error {
+	dType := results.Type().Elem()
+	for i := 0; i < dType.NumField(); i++ {
+		if dType.Field(i).Type != metaType || !dType.Field(i).IsExported() {
+			continue
+		}
+		for j := 0; j < results.Len(); j++ {
+			meta := Meta{
+				Index:     j,
+				URL:       url,
+				FetchedAt: fetchedAt,
+				Selectors: selectors,
+			}
+			if j < len(rows) && rows[j] != nil && rows[j].Length() > 0 {
+				var err error
+				meta.Path = cssPath(rows[j])
+				meta.HTML, err = goquery.OuterHtml(rows[j])
+				if err != nil {
+					return fmt.Errorf("failed to render row %d: %w", j, err)
+				}
+			}
+			results.Index(j).Field(i).Set(reflect.ValueOf(meta))
+		}
+	}
+	return nil
+}
+
+// setMetaIndex sets the Index of the Meta fields of the row to the index.
+func setMetaIndex(row reflect.Value, index int) {
+	for i := 0; i < row.NumField(); i++ {
+		if row.Type().Field(i).Type != metaType || !row.Type().Field(i).IsExported() {
+			continue
+		}
+		row.Field(i).Addr().Interface().(*Meta).Index = index
+	}
+}
+
+// cssPath returns a css selector path uniquely matching the element.
+//
+// For example: "html > body > table > tbody > tr:nth-child(2)".
+func cssPath(sel *goquery.Selection) string {
+	parts := make([]string, 0)
+	for el := sel.First(); el.Length() > 0; el = el.Parent() {
+		name := goquery.NodeName(el)
+		switch name {
+		case "html", "head", "body":
+			parts = append(parts, name)
+		default:
+			parts = append(parts, fmt.Sprintf(
+				"%s:nth-child(%d)",
+				name,
+				el.PrevAll().Length()+1,
+			))
+		}
+	}
+	for i, j := 0, len(parts)-1; i < j; i, j = i+1, j-1 {
+		parts[i], parts[j] = parts[j], parts[i]
+	}
+	return strings.Join(parts, " > ")
+}
@@ -0,0 +1,70 @@
+package seltabl
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/stretchr/testify/assert"
+)
+
+// MetaStruct is a test struct with a Meta field.
+type MetaStruct struct {
+	A    string `dSel:"tr:not(:first-child) td:nth-child(1)"`
+	B    string `dSel:"tr:not(:first-child) td:nth-child(2)"`
+	Meta Meta
+}
+
+// TestNewFromString_Meta tests that Meta fields are filled with the row's
+// provenance.
+func TestNewFromString_Meta(t *testing.T) {
+	t.Parallel()
+	result, err := NewFromString[MetaStruct](basicHTML)
+	assert.NoError(t, err)
+	assert.Len(t, result, 4)
+	meta := result[1].Meta
+	assert.Equal(t, 1, meta.Index)
+	assert.Empty(t, meta.URL)
+	assert.True(t, meta.FetchedAt.IsZero())
+	assert.Equal(t, "html > body > table:nth-child(1) > tbody:nth-child(1) > tr:nth-child(3)", meta.Path)
+	assert.Equal(t, "<tr> <td>3</td> <td>4</td> </tr>", meta.HTML)
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(basicHTML))
+	assert.NoError(t, err)
+	assert.Equal(t, "3", strings.TrimSpace(doc.Find(meta.Path).Find("td").First().Text()))
+}
+
+// TestNewFromURL_Meta tests that Meta fields record the fetched url and
+// time.
+func TestNewFromURL_Meta(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintln(w, basicHTML)
+	}))
+	defer server.Close()
+	before := time.Now()
+	result, err := NewFromURL[MetaStruct](server.URL + "/table")
+	assert.NoError(t, err)
+	assert.Equal(t, server.URL+"/table", result[0].Meta.URL)
+	assert.False(t, result[0].Meta.FetchedAt.Before(before))
+}
+
+// TestNewFromURLPaginated_MetaIgnoredForDuplicates tests that Meta fields do
+// not prevent duplicate rows from being removed.
+func TestNewFromURLPaginated_MetaIgnoredForDuplicates(t *testing.T) {
+	t.Parallel()
+	server := newPaginatedServer(t)
+	result, err := NewFromURLPaginated[MetaStruct](
+		context.Background(),
+		server.URL+"/?page=1",
+		Pagination{NextSelector: "a.next"},
+	)
+	assert.NoError(t, err)
+	assert.Len(t, result, 4)
+	assert.Equal(t, server.URL+"/?page=2", result[2].Meta.URL)
+	assert.Equal(t, 1, result[2].Meta.Index)
+}
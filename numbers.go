@@ -0,0 +1,269 @@
+package seltabl
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// numberFormat is how a locale writes numbers.
+type numberFormat struct {
+	thousands string // characters used to group thousands
+	decimal   rune   // character separating the fraction
+}
+
+var (
+	// locales are the number formats usable in a locale tag by name.
+	//
+	// Spaces, including non-breaking and thin spaces, are always accepted
+	// as thousands separators.
+	locales = map[string]numberFormat{
+		"en":    {thousands: ",", decimal: '.'},
+		"ja":    {thousands: ",", decimal: '.'},
+		"zh":    {thousands: ",", decimal: '.'},
+		"de":    {thousands: ".'", decimal: ','},
+		"es":    {thousands: ".", decimal: ','},
+		"it":    {thousands: ".", decimal: ','},
+		"nl":    {thousands: ".", decimal: ','},
+		"pt":    {thousands: ".", decimal: ','},
+		"da":    {thousands: ".", decimal: ','},
+		"id":    {thousands: ".", decimal: ','},
+		"tr":    {thousands: ".", decimal: ','},
+		"fr":    {thousands: "", decimal: ','},
+		"ru":    {thousands: "", decimal: ','},
+		"pl":    {thousands: "", decimal: ','},
+		"cs":    {thousands: "", decimal: ','},
+		"sv":    {thousands: "", decimal: ','},
+		"fi":    {thousands: "", decimal: ','},
+		"nb":    {thousands: "", decimal: ','},
+		"de-ch": {thousands: "'’", decimal: '.'},
+		"fr-ch": {thousands: "'’", decimal: '.'},
+		"it-ch": {thousands: "'’", decimal: '.'},
+	}
+
+	// siSuffixes are the powers of ten of the suffixes a number can end in.
+	siSuffixes = map[string]int{
+		"k":  3,
+		"K":  3,
+		"M":  6,
+		"G":  9,
+		"B":  9,
+		"bn": 9,
+		"T":  12,
+	}
+
+	// numberPattern matches the first number within surrounding text.
+	numberPattern = regexp.MustCompile(
+		`[-+\x{2212}]?\d(?:[\d.,'’\s]*\d)?(?:[eE][-+]?\d+)?(?:(?:bn|[kKMGBT])\b)?`,
+	)
+
+	// numberJoiners are the characters that continue a number unless followed
+	// by a space, such as the separators of "1,234" or the dash of "3-4".
+	numberJoiners = "-+\u2212.,'’"
+
+	// errNotANumber is returned when no number is found in a value.
+	errNotANumber = errors.New("not a number")
+	// errNotAnInteger is returned when an integer field is given a fraction.
+	errNotAnInteger = errors.New("not an integer")
+	// errOutOfRange is returned when a number does not fit in its field.
+	errOutOfRange = errors.New("value out of range")
+)
+
+// lookupLocale returns the number format of the named locale.
+//
+// Region specific locales such as "de-AT" fall back to their language when
+// they have no format of their own. An empty name is english.
+func lookupLocale(name string) (numberFormat, error) {
+	if name == "" {
+		return locales["en"], nil
+	}
+	name = strings.ToLower(strings.ReplaceAll(name, "_", "-"))
+	if format, ok := locales[name]; ok {
+		return format, nil
+	}
+	lang, _, _ := strings.Cut(name, "-")
+	if format, ok := locales[lang]; ok {
+		return format, nil
+	}
+	return numberFormat{}, fmt.Errorf("unknown locale: %s", name)
+}
+
+// parseNumber parses a number written in the given format into an exact
+// rational.
+//
+// It accepts a leading sign (including the unicode minus), parentheses for
+// negatives as in accounting, thousands separators, currency symbols,
+// percent signs, scientific notation and the SI suffixes k, M, G/B/bn and
+// T. A percent sign is dropped rather than dividing by a hundred, so "45%"
+// is 45.
+//
+// If the whole value is not a number, the first number standing apart from
+// the text around it is used, so "12 pts" is 12 while "3-4" and "1e" are not
+// numbers. An empty value is 0.
+func parseNumber(value string, format numberFormat) (*big.Rat, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return new(big.Rat), nil
+	}
+	if n, err := parseExactNumber(value, format); err == nil {
+		return n, nil
+	}
+	loc := numberPattern.FindStringIndex(value)
+	if loc == nil || !standsApart(value, loc[0], loc[1]) {
+		return nil, errNotANumber
+	}
+	return parseExactNumber(value[loc[0]:loc[1]], format)
+}
+
+// standsApart reports whether the number at value[start:end] is separated
+// from the text around it, rather than being part of a malformed number such
+// as the mantissa of "1e", the exponent of "e5" or the start of "3-4".
+func standsApart(value string, start, end int) bool {
+	before, _ := utf8.DecodeLastRuneInString(value[:start])
+	if start > 0 && (unicode.IsLetter(before) || unicode.IsDigit(before) ||
+		strings.ContainsRune(numberJoiners, before)) {
+		return false
+	}
+	after, size := utf8.DecodeRuneInString(value[end:])
+	if end == len(value) {
+		return true
+	}
+	if unicode.IsLetter(after) || unicode.IsDigit(after) {
+		return false
+	}
+	next, _ := utf8.DecodeRuneInString(value[end+size:])
+	return !strings.ContainsRune(numberJoiners, after) || unicode.IsSpace(next)
+}
+
+// parseExactNumber parses a value that is entirely a number.
+//
+// Thousands separators must separate groups of three digits, such as
+// "1,234,567", and an exponent must follow a mantissa and have digits.
+func parseExactNumber(value string, format numberFormat) (*big.Rat, error) {
+	value = strings.Map(func(r rune) rune {
+		switch {
+		case r == '−':
+			return '-'
+		case r == '%', r == '‰', unicode.Is(unicode.Sc, r):
+			return -1
+		}
+		return r
+	}, value)
+	value = strings.TrimSpace(value)
+	negative := false
+	if strings.HasPrefix(value, "(") && strings.HasSuffix(value, ")") {
+		negative = true
+		value = strings.TrimSpace(value[1 : len(value)-1])
+	}
+	switch {
+	case strings.HasPrefix(value, "-"):
+		negative = !negative
+		value = value[1:]
+	case strings.HasPrefix(value, "+"):
+		value = value[1:]
+	}
+	if strings.HasPrefix(value, "(") && strings.HasSuffix(value, ")") {
+		negative = !negative
+		value = value[1 : len(value)-1]
+	}
+	exponent := 0
+	for suffix, power := range siSuffixes {
+		trimmed, ok := strings.CutSuffix(value, suffix)
+		if ok && trimmed != "" && unicode.IsDigit(rune(trimmed[len(trimmed)-1])) {
+			value, exponent = trimmed, power
+			break
+		}
+	}
+	if i := strings.LastIndexAny(value, "eE"); i > 0 {
+		exp, err := strconv.Atoi(value[i+1:])
+		if err != nil {
+			return nil, errNotANumber
+		}
+		value, exponent = value[:i], exponent+exp
+	}
+	var b strings.Builder
+	fraction := false
+	grouped := false // whether a thousands separator was seen
+	group := 0       // digits of the integer part since the last separator
+	for _, r := range value {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+			if !fraction {
+				group++
+			}
+		case r == format.decimal && !fraction:
+			if grouped && group != 3 {
+				return nil, errNotANumber
+			}
+			b.WriteRune('.')
+			fraction = true
+		case !fraction &&
+			(unicode.IsSpace(r) || strings.ContainsRune(format.thousands, r)):
+			if group < 1 || group > 3 || grouped && group != 3 {
+				return nil, errNotANumber
+			}
+			grouped, group = true, 0
+		default:
+			return nil, errNotANumber
+		}
+	}
+	if grouped && !fraction && group != 3 {
+		return nil, errNotANumber
+	}
+	digits := strings.TrimSuffix(b.String(), ".")
+	if strings.Trim(digits, ".") == "" {
+		return nil, errNotANumber
+	}
+	n, ok := new(big.Rat).SetString("0" + digits + "e" + strconv.Itoa(exponent))
+	if !ok {
+		return nil, errNotANumber
+	}
+	if negative {
+		n.Neg(n)
+	}
+	return n, nil
+}
+
+// setNumber sets an int, uint or float field to a number parsed from the
+// value, returning an error if it does not fit in the field.
+func setNumber(field *reflect.Value, value string, format numberFormat) error {
+	n, err := parseNumber(value, format)
+	if err != nil {
+		return err
+	}
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if !n.IsInt() {
+			return errNotAnInteger
+		}
+		if !n.Num().IsInt64() || field.OverflowInt(n.Num().Int64()) {
+			return errOutOfRange
+		}
+		field.SetInt(n.Num().Int64())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if !n.IsInt() {
+			return errNotAnInteger
+		}
+		if !n.Num().IsUint64() || field.OverflowUint(n.Num().Uint64()) {
+			return errOutOfRange
+		}
+		field.SetUint(n.Num().Uint64())
+	case reflect.Float32, reflect.Float64:
+		f, _ := n.Float64()
+		if field.OverflowFloat(f) || math.IsInf(f, 0) {
+			return errOutOfRange
+		}
+		field.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported type: %s", field.Kind())
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package seltabl
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSetNumber tests parsing numbers into fields of each numeric kind.
+func TestSetNumber(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		value   string
+		locale  string
+		want    interface{}
+		wantErr error
+	}{
+		{name: "int", value: "123", want: 123},
+		{name: "empty", value: "", want: 0},
+		{name: "negative", value: "-5", want: -5},
+		{name: "unicode minus", value: "−5", want: -5},
+		{name: "plus", value: "+5", want: 5},
+		{name: "parenthesised", value: "(1,234)", want: -1234},
+		{name: "currency", value: "$1,234", want: 1234},
+		{name: "negative currency", value: "-€12", want: -12},
+		{name: "accounting currency", value: "($1,234)", want: -1234},
+		{name: "percent", value: "45%", want: 45},
+		{name: "kilo", value: "1.5k", want: 1500},
+		{name: "mega", value: "2M", want: 2000000},
+		{name: "billion", value: "3bn", want: 3000000000},
+		{name: "scientific", value: "1.2e3", want: 1200},
+		{name: "surrounding text", value: "12 pts", want: 12},
+		{name: "spaces", value: "1 234 567", want: 1234567},
+		{name: "non-breaking space", value: "1 234", want: 1234},
+		{name: "de", value: "1.234", locale: "de", want: 1234},
+		{name: "de region", value: "-1.234", locale: "de-AT", want: -1234},
+		{name: "fraction", value: "2.5", wantErr: errNotAnInteger},
+		{name: "not a number", value: "invalid", wantErr: errNotANumber},
+		{name: "dangling exponent", value: "1e", wantErr: errNotANumber},
+		{name: "missing mantissa", value: "e5", wantErr: errNotANumber},
+		{name: "short groups", value: "1,2,3", wantErr: errNotANumber},
+		{name: "short last group", value: "1,234,5", wantErr: errNotANumber},
+		{name: "long first group", value: "1234,567", wantErr: errNotANumber},
+		{name: "trailing separator", value: "123,", wantErr: errNotANumber},
+		{name: "range", value: "3-4", wantErr: errNotANumber},
+		{name: "text before sign", value: "x-12 pts", wantErr: errNotANumber},
+		{name: "surrounding punctuation", value: "Wins: 12, Losses: 3", want: 12},
+		{name: "grouped fraction", value: "12,345.5 pts", want: 12345.5},
+		{name: "int8 overflow", value: "128", want: int8(0), wantErr: errOutOfRange},
+		{name: "int8", value: "-128", want: int8(-128)},
+		{name: "int64 overflow", value: "9223372036854775808", want: int64(0), wantErr: errOutOfRange},
+		{name: "uint8 overflow", value: "256", want: uint8(0), wantErr: errOutOfRange},
+		{name: "uint negative", value: "-1", want: uint(0), wantErr: errOutOfRange},
+		{name: "uint32", value: "4,294,967,295", want: uint32(4294967295)},
+		{name: "uint32 overflow", value: "4.3bn", want: uint32(0), wantErr: errOutOfRange},
+		{name: "float", value: "1,234.56", want: 1234.56},
+		{name: "float de", value: "1.234,56", locale: "de", want: 1234.56},
+		{name: "float fr", value: "1 234,56 €", locale: "fr", want: 1234.56},
+		{name: "float de-ch", value: "1'234.56", locale: "de-CH", want: 1234.56},
+		{name: "float wrong locale", value: "1.234,56", want: 0.0, wantErr: errNotANumber},
+		{name: "float negative scientific", value: "-2.5E-3", want: -0.0025},
+		{name: "float32", value: "1.45", want: float32(1.45)},
+		{name: "float32 overflow", value: "1e39", want: float32(0), wantErr: errOutOfRange},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			want := tt.want
+			if want == nil {
+				want = 0
+			}
+			field := reflect.New(reflect.TypeOf(want)).Elem()
+			format, err := lookupLocale(tt.locale)
+			assert.NoError(t, err)
+			err = setNumber(&field, tt.value, format)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "expected %v, got %v", tt.wantErr, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, want, field.Interface())
+		})
+	}
+}
+
+// TestNewFromString_Locale tests decoding numbers with a locale tag.
+func TestNewFromString_Locale(t *testing.T) {
+	t.Parallel()
+	type Price struct {
+		Item  string  `dSel:"tr td:nth-child(1)"`
+		Price float64 `dSel:"tr td:nth-child(2)" locale:"de"`
+		Stock int8    `dSel:"tr td:nth-child(3)"`
+	}
+	result, err := NewFromString[Price](`
+		<table>
+			<tr> <td>Kaffee</td> <td>1.234,50 €</td> <td>(12)</td> </tr>
+		</table>`)
+	assert.NoError(t, err)
+	assert.Equal(t, []Price{{Item: "Kaffee", Price: 1234.5, Stock: -12}}, result)
+
+	_, err = NewFromString[Price](`
+		<table>
+			<tr> <td>Tee</td> <td>2,00</td> <td>300</td> </tr>
+		</table>`)
+	assert.ErrorContains(t, err, "value out of range")
+
+	type Unknown struct {
+		Price float64 `dSel:"tr td" locale:"xx"`
+	}
+	_, err = NewFromString[Unknown](`<table><tr><td>1</td></tr></table>`)
+	assert.ErrorContains(t, err, "unknown locale")
+}
@@ -0,0 +1,187 @@
+package seltabl
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// keyLabelSelector selects the cells that can label a row of a vertical
+// table or the terms of a description list.
+const keyLabelSelector = "tr > th, tr > td:first-child, dt"
+
+// NewOne parses a goquery doc into a single struct.
+//
+// Each field is set from the first cell its data selector (dSel) matches,
+// or, for fields with a key tag, from the cell labelled by the key in a
+// vertical table such as an infobox:
+//
+//	<tr> <th>Founded</th> <td>1890</td> </tr>
+//
+// The label is the header cell or first cell of a table row, or the term of
+// a description list, and the labelled cell is the one after it. Labels
+// match ignoring case, surrounding space and a trailing colon. The data
+// selector of a field with a key tag, if any, scopes the search to the
+// elements it matches.
+//
+// Example:
+//
+//	type Team struct {
+//		Name    string `dSel:"table.infobox caption"`
+//		Founded int    `key:"Founded"`
+//		Stadium string `key:"Stadium" dSel:"table.infobox"`
+//	}
+//
+//	team, err := seltabl.NewOne[Team](doc)
+func NewOne[T any](doc *goquery.Document, opts ...Option) (T, error) {
+	return newOneWithOptions[T](doc, newOptions(opts))
+}
+
+// newOneWithOptions parses a goquery doc into a single struct using the
+// given options.
+func newOneWithOptions[T any](doc *goquery.Document, o *options) (T, error) {
+	var result T
+	dType := reflect.TypeOf((*T)(nil)).Elem()
+	if dType.Kind() != reflect.Struct {
+		return result, fmt.Errorf("expected struct, got %s", dType.Kind())
+	}
+	results, err := decodeOne(doc, dType, o)
+	if err != nil {
+		return result, err
+	}
+	return results.Index(0).Interface().(T), nil
+}
+
+// NewOneFromString parses a string into a single struct.
+//
+// See NewOne for how fields are set.
+func NewOneFromString[T any](htmlInput string, opts ...Option) (T, error) {
+	return NewOneFromReader[T](strings.NewReader(htmlInput), opts...)
+}
+
+// NewOneFromReader parses a reader into a single struct.
+//
+// See NewOne for how fields are set.
+func NewOneFromReader[T any](r io.Reader, opts ...Option) (T, error) {
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		var result T
+		return result, fmt.Errorf("failed to parse html: %w", err)
+	}
+	return NewOne[T](doc, opts...)
+}
+
+// NewOneFromURL parses a given URL's html into a single struct.
+//
+// See NewOne for how fields are set.
+func NewOneFromURL[T any](url string, opts ...Option) (T, error) {
+	o := newOptions(opts)
+	doc, err := fetchDocument(o.ctx, o.client, url)
+	if err != nil {
+		var result T
+		return result, err
+	}
+	o.fetchedAt = time.Now()
+	return newOneWithOptions[T](doc, o)
+}
+
+// decodeOne parses a goquery doc into a slice holding a single struct of the
+// given type.
+func decodeOne(
+	doc *goquery.Document,
+	dType reflect.Type,
+	o *options,
+) (reflect.Value, error) {
+	results := reflect.MakeSlice(reflect.SliceOf(dType), 1, 1)
+	base := documentBase(doc, o.baseURL)
+	err := validateDocument(doc, dType)
+	if err != nil {
+		return results, err
+	}
+	selectors := chooseSelectors(doc.Selection, dType, o)
+	for i := 0; i < dType.NumField(); i++ {
+		field := dType.Field(i)
+		cfg := NewSelectorConfig(field.Tag)
+		var cell *goquery.Selection
+		switch {
+		case cfg.Key != "":
+			scope := doc.Selection
+			if cfg.DataSelector != "" {
+				scope = find(doc.Selection, cfg.DataSelector)
+			}
+			cell = findByKey(scope, cfg.Key)
+		case cfg.DataSelector != "":
+			cell = find(doc.Selection, cfg.DataSelector)
+			if cfg.HeadSelector != "" && cfg.HeadSelector != "-" {
+				cell = not(cell, cfg.HeadSelector)
+			}
+			cell = cell.First()
+		default:
+			continue
+		}
+		if cell.Length() == 0 {
+			if !cfg.HasDefault {
+				return results, ErrSelectorNotFound{
+					Typ:   dType,
+					Field: field,
+					Cfg:   cfg,
+				}
+			}
+			err = setMissingCell(results, 0, field, cfg, cfg.Follow == "")
+			if err != nil {
+				return results, err
+			}
+			continue
+		}
+		if cfg.Follow != "" {
+			err = followField(base, results, field, cell, []int{0}, cfg, o)
+			if err != nil {
+				return results, fmt.Errorf(
+					"failed to follow field %s: %w",
+					field.Name,
+					err,
+				)
+			}
+			continue
+		}
+		err = setCell(results, 0, field, cell, cfg, newSelector(cfg, base))
+		if err != nil {
+			return results, err
+		}
+	}
+	err = setMeta(results, nil, documentURL(doc, o.baseURL), o.fetchedAt, selectors)
+	if err != nil {
+		return results, err
+	}
+	return results, nil
+}
+
+// findByKey returns the cell labelled by the key within the scope, or an
+// empty selection if there is none.
+func findByKey(scope *goquery.Selection, key string) *goquery.Selection {
+	key = normalizeLabel(key)
+	var cell *goquery.Selection
+	scope.Find(keyLabelSelector).EachWithBreak(
+		func(_ int, label *goquery.Selection) bool {
+			if normalizeLabel(label.Text()) != key {
+				return true
+			}
+			cell = label.Next()
+			return cell.Length() == 0
+		},
+	)
+	if cell == nil {
+		return scope.Slice(0, 0)
+	}
+	return cell
+}
+
+// normalizeLabel normalizes the text of a label for comparison.
+func normalizeLabel(label string) string {
+	label = strings.Join(strings.Fields(label), " ")
+	return strings.ToLower(strings.TrimSpace(strings.TrimSuffix(label, ":")))
+}
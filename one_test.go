@@ -0,0 +1,85 @@
+package seltabl
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// infoboxHTML is a page with an infobox style vertical table.
+const infoboxHTML = `
+	<h1>Iowa State Cyclones</h1>
+	<table class="infobox">
+		<caption>Iowa State Cyclones football</caption>
+		<tr> <th>Founded</th> <td>1892</td> </tr>
+		<tr> <th>Stadium:</th> <td><a href="/jack-trice">Jack Trice Stadium</a></td> </tr>
+		<tr> <th> Head  coach </th> <td>Matt Campbell</td> </tr>
+	</table>
+	<table class="other">
+		<tr> <th>Stadium</th> <td>Other Stadium</td> </tr>
+	</table>
+	<dl>
+		<dt>Colors</dt> <dd>Cardinal and gold</dd>
+	</dl>`
+
+// Team is a struct decoded from infoboxHTML.
+type Team struct {
+	Name    string `dSel:"h1"`
+	Caption string `dSel:"table.infobox caption"`
+	Founded int    `key:"Founded"`
+	Stadium string `key:"stadium" dSel:"table.infobox"`
+	Link    string `key:"Stadium" dSel:"table.infobox" ctl:"html"`
+	Coach   string `key:"Head Coach"`
+	Colors  string `key:"Colors"`
+	Mascot  string `key:"Mascot" default:"Cy"`
+	Meta    Meta
+}
+
+// TestNewOneFromString tests decoding a single struct.
+func TestNewOneFromString(t *testing.T) {
+	t.Parallel()
+	team, err := NewOneFromString[Team](infoboxHTML)
+	assert.NoError(t, err)
+	assert.Equal(t, "Iowa State Cyclones", team.Name)
+	assert.Equal(t, "Iowa State Cyclones football", team.Caption)
+	assert.Equal(t, 1892, team.Founded)
+	assert.Equal(t, "Jack Trice Stadium", team.Stadium)
+	assert.Equal(t, `<a href="/jack-trice">Jack Trice Stadium</a>`, team.Link)
+	assert.Equal(t, "Matt Campbell", team.Coach)
+	assert.Equal(t, "Cardinal and gold", team.Colors)
+	assert.Equal(t, "Cy", team.Mascot)
+	assert.Equal(t, 0, team.Meta.Index)
+
+	type Missing struct {
+		Record string `key:"Record"`
+	}
+	_, err = NewOneFromString[Missing](infoboxHTML)
+	var notFound ErrSelectorNotFound
+	assert.ErrorAs(t, err, &notFound)
+
+	_, err = NewOneFromString[int](infoboxHTML)
+	assert.Error(t, err)
+}
+
+// TestNewOneFromURL tests decoding a single struct from a url.
+func TestNewOneFromURL(t *testing.T) {
+	t.Parallel()
+	server := httpTestServer(t, infoboxHTML)
+	defer server.Close()
+	team, err := NewOneFromURL[Team](server.URL)
+	assert.NoError(t, err)
+	assert.Equal(t, 1892, team.Founded)
+	assert.Equal(t, server.URL, team.Meta.URL)
+	assert.False(t, team.Meta.FetchedAt.IsZero())
+}
+
+// TestDecoder_DecodeOne tests the Decoder.DecodeOne function.
+func TestDecoder_DecodeOne(t *testing.T) {
+	t.Parallel()
+	decoder := NewDecoder[Team](io.NopCloser(strings.NewReader(infoboxHTML)))
+	team, err := decoder.DecodeOne()
+	assert.NoError(t, err)
+	assert.Equal(t, "Matt Campbell", team.Coach)
+}
@@ -0,0 +1,145 @@
+package seltabl
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+const (
+	// defaultFollowLimit is the default number of links followed at once.
+	defaultFollowLimit = 4
+)
+
+// Option configures how documents are fetched and decoded.
+//
+// Options are accepted by New and the functions built on top of it.
+type Option func(*options)
+
+// options are the settings configured by Options.
+type options struct {
+	ctx         context.Context
+	client      *http.Client
+	baseURL     *url.URL
+	fetchedAt   time.Time
+	followLimit int
+	follower    *follower
+	following   map[string]bool
+	alignment   Alignment
+	warn        func(err error)
+	choice      func(choice SelectorChoice)
+	rows        []*goquery.Selection
+}
+
+// newOptions applies the given options on top of the defaults.
+func newOptions(opts []Option) *options {
+	o := &options{
+		ctx:         context.Background(),
+		client:      &http.Client{},
+		followLimit: defaultFollowLimit,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.follower == nil {
+		o.follower = newFollower(o.client, o.followLimit)
+	}
+	return o
+}
+
+// forPage returns a copy of the options for decoding a page fetched at the
+// given time.
+//
+// The base url and rows are cleared so that the page's own url and rows are
+// used.
+func (o *options) forPage(fetchedAt time.Time) *options {
+	page := *o
+	page.baseURL = nil
+	page.rows = nil
+	page.fetchedAt = fetchedAt
+	return &page
+}
+
+// forFollowed returns a copy of the options for decoding the page of a
+// followed link, adding the link to the links being followed.
+func (o *options) forFollowed(link string, fetchedAt time.Time) *options {
+	page := o.forPage(fetchedAt)
+	page.following = make(map[string]bool, len(o.following)+1)
+	for followed := range o.following {
+		page.following[followed] = true
+	}
+	page.following[link] = true
+	return page
+}
+
+// forRows returns a copy of the options for decoding the given row elements
+// instead of those selected by the struct's tags.
+func (o *options) forRows(rows []*goquery.Selection) *options {
+	r := *o
+	r.rows = rows
+	return &r
+}
+
+// WithContext sets the context used for any requests made while decoding.
+func WithContext(ctx context.Context) Option {
+	return func(o *options) {
+		o.ctx = ctx
+	}
+}
+
+// WithHTTPClient sets the http client used for any requests made while
+// decoding.
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *options) {
+		o.client = client
+	}
+}
+
+// WithFollowLimit sets the maximum number of links fetched at once for
+// fields with a follow tag. Defaults to 4.
+func WithFollowLimit(limit int) Option {
+	return func(o *options) {
+		if limit > 0 {
+			o.followLimit = limit
+		}
+	}
+}
+
+// WithBaseURL sets the url of the document being decoded.
+//
+// Relative urls selected with the url control or followed with the follow
+// tag are resolved against it, or against the document's <base href> if it
+// has one. NewFromURL sets it to the fetched url automatically.
+func WithBaseURL(base *url.URL) Option {
+	return func(o *options) {
+		o.baseURL = base
+	}
+}
+
+// WithAlignment sets how the cells selected for each field are grouped into
+// rows. Defaults to AlignByIndex.
+func WithAlignment(alignment Alignment) Option {
+	return func(o *options) {
+		o.alignment = alignment
+	}
+}
+
+// WithWarnings sets a function called with problems found while decoding
+// that do not stop it, such as an ErrMisaligned when aligning by index.
+func WithWarnings(warn func(err error)) Option {
+	return func(o *options) {
+		o.warn = warn
+	}
+}
+
+// WithSelectorChoices sets a function called with the alternative chosen
+// from each selector chain of the header and data selectors of the fields,
+// such as "table#stats td || table.wikitable td".
+func WithSelectorChoices(choice func(choice SelectorChoice)) Option {
+	return func(o *options) {
+		o.choice = choice
+	}
+}
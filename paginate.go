@@ -0,0 +1,212 @@
+package seltabl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// pagePlaceholder is the placeholder replaced with the page number in a
+	// Pagination's URLTemplate.
+	pagePlaceholder = "{page}"
+)
+
+// Pagination configures how NewFromURLPaginated moves from one page of a
+// table to the next.
+//
+// Either NextSelector or URLTemplate must be set. When both are set the
+// NextSelector is used.
+type Pagination struct {
+	// NextSelector selects the anchor linking to the next page.
+	//
+	// The href attribute of the first match is followed. Relative links are
+	// resolved against the url of the page they were found on.
+	NextSelector string
+	// URLTemplate is a url containing a "{page}" placeholder that is replaced
+	// with the number of the page to fetch.
+	URLTemplate string
+	// StartPage is the page number of the initial url given to
+	// NewFromURLPaginated. Defaults to 1.
+	StartPage int
+	// MaxPages is the maximum number of pages to fetch. Zero means no limit.
+	MaxPages int
+}
+
+// NewFromURLPaginated parses a table split across multiple pages into a slice
+// of structs adhering to the given generic type.
+//
+// The given url is fetched and decoded like NewFromURL. Afterwards the next
+// page is found using the given Pagination until there are no more pages,
+// a page has no rows, a page only has rows of earlier pages, a page of the
+// URLTemplate responds with 404 Not Found, or MaxPages is reached.
+//
+// Rows from every page are concatenated in order. Rows repeating a row of an
+// earlier page, such as when a page boundary shifts, are removed while
+// identical rows within a page are kept. Linked pages followed by fields with
+// a follow tag are cached across every page.
+//
+// Any error, including the context being cancelled, returns no rows.
+//
+// Example:
+//
+//	rows, err := seltabl.NewFromURLPaginated[TableStruct](
+//		ctx,
+//		"https://example.com/stats?page=1",
+//		seltabl.Pagination{
+//			NextSelector: "a.next",
+//			MaxPages:     10,
+//		},
+//	)
+func NewFromURLPaginated[T any](
+	ctx context.Context,
+	url string,
+	pagination Pagination,
+	opts ...Option,
+) ([]T, error) {
+	if pagination.NextSelector == "" && pagination.URLTemplate == "" {
+		return nil, fmt.Errorf("pagination requires a next selector or url template")
+	}
+	page := pagination.StartPage
+	if page == 0 {
+		page = 1
+	}
+	o := newOptions(append(opts[:len(opts):len(opts)], WithContext(ctx)))
+	results := make([]T, 0)
+	seen := make(map[string]bool)
+	visited := make(map[string]bool)
+	for fetched := 0; pagination.MaxPages == 0 || fetched < pagination.MaxPages; fetched++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		visited[url] = true
+		doc, err := fetchDocument(ctx, o.client, url)
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, ctxErr
+			}
+			if fetched > 0 && pagination.NextSelector == "" && isNotFound(err) {
+				break
+			}
+			return nil, fmt.Errorf("failed to fetch page %d: %w", page, err)
+		}
+		rows, err := newWithOptions[T](doc, o.forPage(time.Now()))
+		if err != nil {
+			if fetched > 0 && isExhausted(err) {
+				break
+			}
+			return nil, fmt.Errorf("failed to decode page %d: %w", page, err)
+		}
+		added := 0
+		keys := make([]string, len(rows))
+		for i, row := range rows {
+			keys[i] = rowKey(reflect.ValueOf(row))
+			if seen[keys[i]] {
+				continue
+			}
+			results = append(results, row)
+			added++
+		}
+		for _, key := range keys {
+			seen[key] = true
+		}
+		if added == 0 {
+			break
+		}
+		page++
+		if pagination.NextSelector == "" {
+			url = strings.ReplaceAll(
+				pagination.URLTemplate,
+				pagePlaceholder,
+				strconv.Itoa(page),
+			)
+			continue
+		}
+		href, exists := doc.Find(pagination.NextSelector).First().Attr("href")
+		if !exists || strings.TrimSpace(href) == "" {
+			break
+		}
+		next, err := doc.Url.Parse(strings.TrimSpace(href))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse next page url %s: %w", href, err)
+		}
+		url = next.String()
+		if visited[url] {
+			break
+		}
+	}
+	return results, nil
+}
+
+// isExhausted returns whether the error returned from decoding a page means
+// that the page has no rows.
+func isExhausted(err error) bool {
+	var notFound ErrSelectorNotFound
+	return errors.As(err, &notFound) || errors.Is(err, errNoDataFound)
+}
+
+// isNotFound returns whether the error returned from fetching a page means
+// that the page does not exist.
+func isNotFound(err error) bool {
+	var status ErrStatus
+	return errors.As(err, &status) && status.StatusCode == http.StatusNotFound
+}
+
+// rowKey returns a key identifying a decoded row for deduplication.
+//
+// Pointers are compared by the values they point to, and Meta fields are
+// ignored as they differ for the same row on different pages.
+func rowKey(row reflect.Value) string {
+	var b strings.Builder
+	writeRowKey(&b, row)
+	return b.String()
+}
+
+// writeRowKey writes the key of the value to the builder.
+func writeRowKey(b *strings.Builder, v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			b.WriteString("nil")
+			return
+		}
+		writeRowKey(b, v.Elem())
+	case reflect.Struct:
+		if v.Type() == metaType {
+			return
+		}
+		b.WriteString("{")
+		for i := 0; i < v.NumField(); i++ {
+			writeRowKey(b, v.Field(i))
+			b.WriteString(",")
+		}
+		b.WriteString("}")
+	case reflect.Slice, reflect.Array:
+		b.WriteString("[")
+		for i := 0; i < v.Len(); i++ {
+			writeRowKey(b, v.Index(i))
+			b.WriteString(",")
+		}
+		b.WriteString("]")
+	case reflect.Map:
+		entries := make([]string, 0, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			var entry strings.Builder
+			writeRowKey(&entry, iter.Key())
+			entry.WriteString(":")
+			writeRowKey(&entry, iter.Value())
+			entries = append(entries, entry.String())
+		}
+		sort.Strings(entries)
+		b.WriteString("map[" + strings.Join(entries, ",") + "]")
+	default:
+		fmt.Fprintf(b, "%#v", v)
+	}
+}
@@ -0,0 +1,213 @@
+package seltabl
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// paginatedPages are the pages served by newPaginatedServer keyed by page
+// number.
+var paginatedPages = map[string]string{
+	"1": `
+		<table>
+			<tr> <td>a</td> <td>b</td> </tr>
+			<tr> <td>1</td> <td>2</td> </tr>
+			<tr> <td>3</td> <td>4</td> </tr>
+		</table>
+		<a class="next" href="/?page=2">next</a>`,
+	"2": `
+		<table>
+			<tr> <td>a</td> <td>b</td> </tr>
+			<tr> <td>3</td> <td>4</td> </tr>
+			<tr> <td>5</td> <td>6</td> </tr>
+		</table>
+		<a class="next" href="?page=3">next</a>`,
+	"3": `
+		<table>
+			<tr> <td>a</td> <td>b</td> </tr>
+			<tr> <td>7</td> <td>8</td> </tr>
+		</table>`,
+	"repeat": `
+		<table>
+			<tr> <td>a</td> <td>b</td> </tr>
+			<tr> <td>1</td> <td>2</td> </tr>
+			<tr> <td>1</td> <td>2</td> </tr>
+		</table>
+		<a class="next" href="?page=fail">next</a>`,
+}
+
+// newPaginatedServer creates a test server serving the paginatedPages.
+func newPaginatedServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			page := r.URL.Query().Get("page")
+			if page == "fail" {
+				w.WriteHeader(http.StatusInternalServerError)
+				fmt.Fprintln(w, "<html><body>error</body></html>")
+				return
+			}
+			body, ok := paginatedPages[page]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				fmt.Fprintln(w, "<html><body>not found</body></html>")
+				return
+			}
+			fmt.Fprintln(w, body)
+		}),
+	)
+	t.Cleanup(server.Close)
+	return server
+}
+
+// TestNewFromURLPaginated_NextSelector tests following next links.
+func TestNewFromURLPaginated_NextSelector(t *testing.T) {
+	t.Parallel()
+	server := newPaginatedServer(t)
+	result, err := NewFromURLPaginated[TestieStruct](
+		context.Background(),
+		server.URL+"/?page=1",
+		Pagination{NextSelector: "a.next"},
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, []TestieStruct{
+		{A: "1", B: "2"},
+		{A: "3", B: "4"},
+		{A: "5", B: "6"},
+		{A: "7", B: "8"},
+	}, result)
+}
+
+// TestNewFromURLPaginated_URLTemplate tests following a url template until a
+// page has no rows.
+func TestNewFromURLPaginated_URLTemplate(t *testing.T) {
+	t.Parallel()
+	server := newPaginatedServer(t)
+	result, err := NewFromURLPaginated[TestieStruct](
+		context.Background(),
+		server.URL+"/?page=1",
+		Pagination{URLTemplate: server.URL + "/?page={page}"},
+	)
+	assert.NoError(t, err)
+	assert.Len(t, result, 4)
+	assert.Equal(t, "7", result[3].A)
+}
+
+// TestNewFromURLPaginated_MaxPages tests that MaxPages limits the pages
+// fetched.
+func TestNewFromURLPaginated_MaxPages(t *testing.T) {
+	t.Parallel()
+	server := newPaginatedServer(t)
+	result, err := NewFromURLPaginated[TestieStruct](
+		context.Background(),
+		server.URL+"/?page=1",
+		Pagination{NextSelector: "a.next", MaxPages: 2},
+	)
+	assert.NoError(t, err)
+	assert.Len(t, result, 3)
+}
+
+// TestNewFromURLPaginated_RepeatedRows tests that identical rows within a
+// page are kept.
+func TestNewFromURLPaginated_RepeatedRows(t *testing.T) {
+	t.Parallel()
+	server := newPaginatedServer(t)
+	result, err := NewFromURLPaginated[TestieStruct](
+		context.Background(),
+		server.URL+"/?page=repeat",
+		Pagination{NextSelector: "a.next", MaxPages: 1},
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, []TestieStruct{{A: "1", B: "2"}, {A: "1", B: "2"}}, result)
+}
+
+// TestNewFromURLPaginated_ServerError tests that a page responding with a
+// server error is an error instead of the end of the pages.
+func TestNewFromURLPaginated_ServerError(t *testing.T) {
+	t.Parallel()
+	server := newPaginatedServer(t)
+	result, err := NewFromURLPaginated[TestieStruct](
+		context.Background(),
+		server.URL+"/?page=repeat",
+		Pagination{NextSelector: "a.next"},
+	)
+	var status ErrStatus
+	assert.ErrorAs(t, err, &status)
+	assert.Equal(t, http.StatusInternalServerError, status.StatusCode)
+	assert.Nil(t, result)
+}
+
+// TestNewFromURLPaginated_Cancelled tests that a cancelled context stops the
+// pagination.
+func TestNewFromURLPaginated_Cancelled(t *testing.T) {
+	t.Parallel()
+	server := newPaginatedServer(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	result, err := NewFromURLPaginated[TestieStruct](
+		ctx,
+		server.URL+"/?page=1",
+		Pagination{NextSelector: "a.next"},
+	)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, result)
+}
+
+// TestNewFromURLPaginated_FirstPageEmpty tests that an empty first page is an
+// error.
+func TestNewFromURLPaginated_FirstPageEmpty(t *testing.T) {
+	t.Parallel()
+	server := newPaginatedServer(t)
+	_, err := NewFromURLPaginated[TestieStruct](
+		context.Background(),
+		server.URL+"/?page=9",
+		Pagination{NextSelector: "a.next"},
+	)
+	assert.Error(t, err)
+}
+
+// TestNewFromURLPaginated_NoStrategy tests that a pagination without a next
+// selector or url template is an error.
+func TestNewFromURLPaginated_NoStrategy(t *testing.T) {
+	t.Parallel()
+	_, err := NewFromURLPaginated[TestieStruct](
+		context.Background(),
+		"http://localhost",
+		Pagination{},
+	)
+	assert.Error(t, err)
+}
+
+// TestRowKey tests that rows are keyed by their values.
+func TestRowKey(t *testing.T) {
+	t.Parallel()
+	type Detail struct {
+		Position string
+		Meta     Meta
+	}
+	type Row struct {
+		Name   string
+		Detail *Detail
+		Tags   map[string]int
+		Meta   Meta
+	}
+	row := func(name, position string, index int) Row {
+		return Row{
+			Name:   name,
+			Detail: &Detail{Position: position, Meta: Meta{Index: index}},
+			Tags:   map[string]int{"a": 1, "b": 2},
+			Meta:   Meta{Index: index, URL: fmt.Sprintf("/?page=%d", index)},
+		}
+	}
+	key := func(r Row) string { return rowKey(reflect.ValueOf(r)) }
+	assert.Equal(t, key(row("Ann", "P1", 0)), key(row("Ann", "P1", 1)))
+	assert.NotEqual(t, key(row("Ann", "P1", 0)), key(row("Ann", "P2", 0)))
+	assert.NotEqual(t, key(row("Ann", "P1", 0)), key(Row{Name: "Ann"}))
+	assert.Equal(t, key(Row{Name: "Ann"}), key(Row{Name: "Ann"}))
+}
@@ -0,0 +1,132 @@
+package seltabl
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+)
+
+const (
+	// pivotKey is the value of a pivot tag marking the field set from the
+	// header of each pivot column.
+	pivotKey = "key"
+	// pivotValue is the value of a pivot tag marking the field set from the
+	// cell of each pivot column.
+	pivotValue = "value"
+)
+
+// pivot is the pivot columns of a struct unpivoted into a row per column.
+//
+// The key field selects the header cells of the pivot columns with its
+// header selector (hSel), and the value field selects their cells with its
+// data selector (dSel). Cells belong to the header in the same column.
+//
+// Example:
+//
+//	type Revenue struct {
+//		Company string  `dSel:"tr:not(:first-child) td:nth-child(1)"`
+//		Year    int     `pivot:"key" hSel:"tr:first-child th:nth-child(n+2)"`
+//		Value   float64 `pivot:"value" dSel:"tr:not(:first-child) td:nth-child(n+2)"`
+//	}
+type pivot struct {
+	key      reflect.StructField
+	keyCfg   *SelectorConfig
+	value    reflect.StructField
+	valueCfg *SelectorConfig
+	headers  map[int]*goquery.Selection          // header cell of each column
+	cells    map[*html.Node][]*goquery.Selection // pivot cells of each row
+}
+
+// matchPivot selects the header cells and cells of the pivot columns of the
+// struct type, returning nil if it has no pivot fields.
+func matchPivot(doc *goquery.Document, dType reflect.Type) (*pivot, error) {
+	p := &pivot{}
+	for i := 0; i < dType.NumField(); i++ {
+		field := dType.Field(i)
+		cfg := NewSelectorConfig(field.Tag)
+		switch cfg.Pivot {
+		case "":
+		case pivotKey:
+			p.key, p.keyCfg = field, cfg
+		case pivotValue:
+			p.value, p.valueCfg = field, cfg
+		default:
+			return nil, fmt.Errorf(
+				"unsupported pivot %s for field %s",
+				cfg.Pivot,
+				field.Name,
+			)
+		}
+	}
+	switch {
+	case p.keyCfg == nil && p.valueCfg == nil:
+		return nil, nil
+	case p.keyCfg == nil || p.valueCfg == nil:
+		return nil, fmt.Errorf("%s needs both a pivot key and a pivot value field", dType)
+	case p.keyCfg.HeadSelector == "":
+		return nil, fmt.Errorf("pivot key field %s needs a header selector", p.key.Name)
+	case p.valueCfg.DataSelector == "":
+		return nil, fmt.Errorf("pivot value field %s needs a data selector", p.value.Name)
+	}
+	p.headers = make(map[int]*goquery.Selection)
+	find(doc.Selection, p.keyCfg.HeadSelector).Each(func(_ int, header *goquery.Selection) {
+		p.headers[column(header)] = header
+	})
+	p.cells = make(map[*html.Node][]*goquery.Selection)
+	find(doc.Selection, p.valueCfg.DataSelector).Each(func(_ int, cell *goquery.Selection) {
+		if _, ok := p.headers[column(cell)]; !ok {
+			return
+		}
+		row := rowNode(rowElement(cell))
+		p.cells[row] = append(p.cells[row], cell)
+	})
+	return p, nil
+}
+
+// unpivot returns a copy of each row for each of its pivot cells, with the
+// key field set from the header of the cell's column and the value field
+// from the cell. Rows without pivot cells are dropped.
+func (p *pivot) unpivot(
+	results reflect.Value,
+	rows []*goquery.Selection,
+	base *url.URL,
+) (reflect.Value, []*goquery.Selection, error) {
+	unpivoted := reflect.MakeSlice(results.Type(), 0, results.Len())
+	unpivotedRows := make([]*goquery.Selection, 0, len(rows))
+	keySelector := newSelector(p.keyCfg, base)
+	valueSelector := newSelector(p.valueCfg, base)
+	for j, row := range rows {
+		for _, cell := range p.cells[rowNode(row)] {
+			unpivoted = reflect.Append(unpivoted, results.Index(j))
+			unpivotedRows = append(unpivotedRows, row)
+			k := unpivoted.Len() - 1
+			err := setCell(unpivoted, k, p.key, p.headers[column(cell)], p.keyCfg, keySelector)
+			if err != nil {
+				return unpivoted, unpivotedRows, err
+			}
+			err = setCell(unpivoted, k, p.value, cell, p.valueCfg, valueSelector)
+			if err != nil {
+				return unpivoted, unpivotedRows, err
+			}
+		}
+	}
+	return unpivoted, unpivotedRows, nil
+}
+
+// column returns the index of the column of a cell in its row, counting the
+// columns spanned by the cells before it.
+func column(cell *goquery.Selection) int {
+	col := 0
+	cell.PrevAll().Each(func(_ int, prev *goquery.Selection) {
+		span, err := strconv.Atoi(prev.AttrOr("colspan", "1"))
+		if err != nil || span < 1 {
+			span = 1
+		}
+		col += span
+	})
+	return col
+}
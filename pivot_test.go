@@ -0,0 +1,68 @@
+package seltabl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// pivotHTML is a table with a column per year.
+const pivotHTML = `
+	<table>
+		<tr> <th>Company</th> <th>2019</th> <th>2020</th> <th>2021</th> </tr>
+		<tr> <td>Acme</td> <td>1.5</td> <td>2</td> <td>2.5</td> </tr>
+		<tr> <td>Globex</td> <td>3</td> <td>3.5</td> <td>4</td> </tr>
+	</table>`
+
+// TestNewFromString_Pivot tests unpivoting columns into rows.
+func TestNewFromString_Pivot(t *testing.T) {
+	t.Parallel()
+	type Revenue struct {
+		Company string  `dSel:"tr:not(:first-child) td:nth-child(1)"`
+		Year    int     `pivot:"key" hSel:"tr:first-child th:nth-child(n+2)"`
+		Value   float64 `pivot:"value" dSel:"tr:not(:first-child) td:nth-child(n+2)"`
+		Meta    Meta
+	}
+	result, err := NewFromString[Revenue](pivotHTML)
+	assert.NoError(t, err)
+	assert.Len(t, result, 6)
+	got := make([]Revenue, len(result))
+	for i, r := range result {
+		assert.Equal(t, i, r.Meta.Index)
+		got[i] = Revenue{Company: r.Company, Year: r.Year, Value: r.Value}
+	}
+	assert.Equal(t, []Revenue{
+		{Company: "Acme", Year: 2019, Value: 1.5},
+		{Company: "Acme", Year: 2020, Value: 2},
+		{Company: "Acme", Year: 2021, Value: 2.5},
+		{Company: "Globex", Year: 2019, Value: 3},
+		{Company: "Globex", Year: 2020, Value: 3.5},
+		{Company: "Globex", Year: 2021, Value: 4},
+	}, got)
+
+	type Colspan struct {
+		Company string `dSel:"tr:not(:first-child) td:nth-child(1)"`
+		Year    string `pivot:"key" hSel:"tr:first-child th:not(:first-child)"`
+		Value   string `pivot:"value" dSel:"tr:not(:first-child) td:not(:first-child)"`
+	}
+	colspan, err := NewFromString[Colspan](`
+		<table>
+			<tr> <th colspan="2">Company</th> <th>2020</th> </tr>
+			<tr> <td>Acme</td> <td>Inc</td> <td>2</td> </tr>
+		</table>`)
+	assert.NoError(t, err)
+	assert.Equal(t, []Colspan{{Company: "Acme", Year: "2020", Value: "2"}}, colspan)
+
+	type KeyOnly struct {
+		Company string `dSel:"tr td:nth-child(1)"`
+		Year    string `pivot:"key" hSel:"tr:first-child th"`
+	}
+	_, err = NewFromString[KeyOnly](pivotHTML)
+	assert.ErrorContains(t, err, "needs both a pivot key and a pivot value field")
+
+	type Unsupported struct {
+		Company string `dSel:"tr td:nth-child(1)" pivot:"row"`
+	}
+	_, err = NewFromString[Unsupported](pivotHTML)
+	assert.ErrorContains(t, err, "unsupported pivot row")
+}
@@ -0,0 +1,252 @@
+package seltabl
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+)
+
+const (
+	// rowSkipTag is the tag used to skip the first rows of a struct.
+	rowSkipTag = "skip"
+	// rowSkipSelectorTag is the tag used to skip the rows of a struct
+	// matching or within an element matching a selector.
+	rowSkipSelectorTag = "skipSel"
+	// rowKeepSelectorTag is the tag used to keep only the rows of a struct
+	// matching or within an element matching a selector.
+	rowKeepSelectorTag = "keepSel"
+	// rowStrideTag is the tag used to decode a struct from every given
+	// number of rows.
+	rowStrideTag = "stride"
+	// rowSelectorTag is the tag used to select the row elements of a struct
+	// that the data selectors of its fields are relative to.
+	rowSelectorTag = "rowSel"
+)
+
+// rowConfig is the configuration of the rows of a struct.
+//
+// It is given by the tags of blank fields of the struct, and applies to the
+// cells of every field.
+//
+// Example:
+//
+//	type Row struct {
+//		_    struct{} `skip:"1" skipSel:"tr.subtotal, tfoot"`
+//		Name string   `dSel:"tr td:nth-child(1)"`
+//	}
+type rowConfig struct {
+	Skip         int    // number of rows skipped at the start
+	SkipSelector string // selector of rows skipped
+	KeepSelector string // selector of rows kept
+	Stride       int    // number of rows decoded into each struct
+	RowSelector  string // selector of the row elements
+}
+
+// newRowConfig returns the row configuration of the struct type.
+func newRowConfig(dType reflect.Type) (*rowConfig, error) {
+	rc := &rowConfig{Stride: 1}
+	for i := 0; i < dType.NumField(); i++ {
+		field := dType.Field(i)
+		if field.Name != "_" {
+			continue
+		}
+		if skip, ok := field.Tag.Lookup(rowSkipTag); ok {
+			n, err := strconv.Atoi(skip)
+			if err != nil || n < 0 {
+				return nil, fmt.Errorf("invalid %s tag %q on %s", rowSkipTag, skip, dType)
+			}
+			rc.Skip = n
+		}
+		if stride, ok := field.Tag.Lookup(rowStrideTag); ok {
+			n, err := strconv.Atoi(stride)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("invalid %s tag %q on %s", rowStrideTag, stride, dType)
+			}
+			rc.Stride = n
+		}
+		if sel, ok := field.Tag.Lookup(rowSkipSelectorTag); ok {
+			rc.SkipSelector = sel
+		}
+		if sel, ok := field.Tag.Lookup(rowKeepSelectorTag); ok {
+			rc.KeepSelector = sel
+		}
+		if sel, ok := field.Tag.Lookup(rowSelectorTag); ok {
+			rc.RowSelector = sel
+		}
+	}
+	return rc, nil
+}
+
+// within reports whether the row matches, or is within an element matching,
+// the selector.
+func within(row *goquery.Selection, selector string) bool {
+	return row.Closest(selector).Length() > 0
+}
+
+// selectRows returns the row elements of the row selector, skipping those
+// excluded by the skip and keep selectors.
+func selectRows(doc *goquery.Document, rc *rowConfig) []*goquery.Selection {
+	rows := make([]*goquery.Selection, 0)
+	find(doc.Selection, rc.RowSelector).Each(func(_ int, row *goquery.Selection) {
+		if rc.SkipSelector != "" && within(row, rc.SkipSelector) ||
+			rc.KeepSelector != "" && !within(row, rc.KeepSelector) {
+			return
+		}
+		rows = append(rows, row)
+	})
+	return rows
+}
+
+// rowScope returns a selection of the row elements.
+func rowScope(doc *goquery.Document, rows []*goquery.Selection) *goquery.Selection {
+	nodes := make([]*html.Node, 0, len(rows))
+	for _, row := range rows {
+		nodes = append(nodes, row.Nodes...)
+	}
+	return doc.FilterNodes().AddNodes(nodes...)
+}
+
+// matchCells selects the cell of each field of the struct type in each of
+// the row elements.
+//
+// The cell of a field is the first element matched by its data selector
+// relative to the row. The alternative chosen from a selector chain, given
+// by the chosen selectors keyed by field name, is used for every row. Rows without one are left out of the field's cells,
+// while rows sharing one, such as nested row elements, each keep it.
+func matchCells(
+	doc *goquery.Document,
+	dType reflect.Type,
+	rows []*goquery.Selection,
+	selectors map[string]string,
+) ([]*fieldMatch, error) {
+	matches := make([]*fieldMatch, 0, dType.NumField())
+	for i := 0; i < dType.NumField(); i++ {
+		cfg := NewSelectorConfig(dType.Field(i).Tag)
+		if !selectsCells(dType.Field(i), cfg) {
+			continue
+		}
+		m := &fieldMatch{index: i, cfg: cfg}
+		selector, ok := selectors[dType.Field(i).Name]
+		if !ok {
+			selector = cfg.DataSelector
+		}
+		cells := make([]*html.Node, 0, len(rows))
+		for j, row := range rows {
+			cell := find(row, selector).First()
+			if cell.Length() == 0 {
+				continue
+			}
+			cells = append(cells, cell.Get(0))
+			m.rows = append(m.rows, row)
+			m.positions = append(m.positions, j)
+		}
+		if len(cells) == 0 && !cfg.HasDefault {
+			return nil, ErrSelectorNotFound{
+				Typ:   dType,
+				Field: dType.Field(i),
+				Cfg:   cfg,
+			}
+		}
+		// the nodes are set directly as AddNodes drops repeated nodes,
+		// such as the cell shared by nested row elements, which would
+		// misalign the cells with their positions.
+		m.cells = doc.FilterNodes()
+		m.cells.Nodes = cells
+		matches = append(matches, m)
+	}
+	return matches, nil
+}
+
+// filterCells removes the cells of each match whose row is skipped by the
+// skip and keep selectors.
+func filterCells(matches []*fieldMatch, rc *rowConfig) {
+	if rc.SkipSelector == "" && rc.KeepSelector == "" {
+		return
+	}
+	for _, m := range matches {
+		rows := make([]*goquery.Selection, 0, len(m.rows))
+		m.cells = m.cells.FilterFunction(func(j int, _ *goquery.Selection) bool {
+			row := m.rows[j]
+			if rc.SkipSelector != "" && within(row, rc.SkipSelector) ||
+				rc.KeepSelector != "" && !within(row, rc.KeepSelector) {
+				return false
+			}
+			rows = append(rows, row)
+			return true
+		})
+		m.rows = rows
+	}
+}
+
+// skipRows removes the first rows and their cells from each match.
+func skipRows(
+	rows []*goquery.Selection,
+	matches []*fieldMatch,
+	rc *rowConfig,
+) []*goquery.Selection {
+	if rc.Skip == 0 {
+		return rows
+	}
+	for _, m := range matches {
+		positions := make([]int, 0, len(m.positions))
+		kept := make([]*goquery.Selection, 0, len(m.rows))
+		m.cells = m.cells.FilterFunction(func(j int, _ *goquery.Selection) bool {
+			if m.positions[j] < rc.Skip {
+				return false
+			}
+			positions = append(positions, m.positions[j]-rc.Skip)
+			kept = append(kept, m.rows[j])
+			return true
+		})
+		m.positions, m.rows = positions, kept
+	}
+	return rows[min(rc.Skip, len(rows)):]
+}
+
+// strideRows groups every stride rows into one, keeping only the cells of
+// each match in the row given by its sub tag.
+//
+// The first row of each group is returned as its row element.
+func strideRows(
+	rows []*goquery.Selection,
+	matches []*fieldMatch,
+	rc *rowConfig,
+) ([]*goquery.Selection, error) {
+	if rc.Stride == 1 {
+		return rows, nil
+	}
+	for _, m := range matches {
+		sub := 0
+		if m.cfg.SubRow != "" {
+			var err error
+			sub, err = strconv.Atoi(m.cfg.SubRow)
+			if err != nil || sub < 0 || sub >= rc.Stride {
+				return nil, fmt.Errorf(
+					"invalid %s tag %q for stride %d",
+					selectorSubRowTag,
+					m.cfg.SubRow,
+					rc.Stride,
+				)
+			}
+		}
+		positions := make([]int, 0, len(m.positions))
+		kept := make([]*goquery.Selection, 0, len(m.rows))
+		m.cells = m.cells.FilterFunction(func(j int, _ *goquery.Selection) bool {
+			if m.positions[j]%rc.Stride != sub {
+				return false
+			}
+			positions = append(positions, m.positions[j]/rc.Stride)
+			kept = append(kept, m.rows[j])
+			return true
+		})
+		m.positions, m.rows = positions, kept
+	}
+	grouped := make([]*goquery.Selection, 0, (len(rows)+rc.Stride-1)/rc.Stride)
+	for j := 0; j < len(rows); j += rc.Stride {
+		grouped = append(grouped, rows[j])
+	}
+	return grouped, nil
+}
@@ -0,0 +1,177 @@
+package seltabl
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// rowsHTML is a table with a header, subtotal and footer rows.
+const rowsHTML = `
+	<table>
+		<thead>
+			<tr> <td>Name</td> <td>Amount</td> </tr>
+		</thead>
+		<tbody>
+			<tr> <td>Rent</td> <td>900</td> </tr>
+			<tr> <td>Food</td> <td>300</td> </tr>
+			<tr class="subtotal"> <td>Subtotal</td> <td>1200</td> </tr>
+			<tr class="ad"> <td colspan="2">Advertisement</td> </tr>
+			<tr> <td>Fuel</td> <td>80</td> </tr>
+		</tbody>
+		<tfoot>
+			<tr> <td>Total</td> <td>1280</td> </tr>
+		</tfoot>
+	</table>`
+
+// TestNewFromString_Rows tests the row skip and keep tags.
+func TestNewFromString_Rows(t *testing.T) {
+	t.Parallel()
+	type Expense struct {
+		_      struct{} `skipSel:"thead, tfoot, tr.subtotal, tr.ad"`
+		Name   string   `dSel:"tr td:nth-child(1)"`
+		Amount int      `dSel:"tr td:nth-child(2)"`
+	}
+	want := []Expense{
+		{Name: "Rent", Amount: 900},
+		{Name: "Food", Amount: 300},
+		{Name: "Fuel", Amount: 80},
+	}
+	result, err := NewFromString[Expense](rowsHTML, WithAlignment(AlignStrict))
+	assert.NoError(t, err)
+	assert.Equal(t, want, result)
+
+	type Kept struct {
+		_      struct{} `keepSel:"tbody" skipSel:"tr.subtotal, tr.ad"`
+		Name   string   `dSel:"tr td:nth-child(1)"`
+		Amount int      `dSel:"tr td:nth-child(2)"`
+	}
+	kept, err := NewFromString[Kept](rowsHTML)
+	assert.NoError(t, err)
+	assert.Equal(t, []Kept{
+		{Name: "Rent", Amount: 900},
+		{Name: "Food", Amount: 300},
+		{Name: "Fuel", Amount: 80},
+	}, kept)
+
+	type Skipped struct {
+		_      struct{} `skip:"1" skipSel:"tfoot, tr.subtotal, tr.ad"`
+		Name   string   `dSel:"tr td:nth-child(1)"`
+		Amount int      `dSel:"tr td:nth-child(2)"`
+		Meta   Meta
+	}
+	skipped, err := NewFromString[Skipped](rowsHTML)
+	assert.NoError(t, err)
+	assert.Len(t, skipped, 3)
+	assert.Equal(t, "Rent", skipped[0].Name)
+	assert.Equal(t, 0, skipped[0].Meta.Index)
+	assert.Contains(t, skipped[0].Meta.HTML, "Rent")
+	assert.Equal(t, 80, skipped[2].Amount)
+
+	type Invalid struct {
+		_    struct{} `skip:"-1"`
+		Name string   `dSel:"tr td:nth-child(1)"`
+	}
+	_, err = NewFromString[Invalid](rowsHTML)
+	assert.ErrorContains(t, err, "invalid skip tag")
+
+	type All struct {
+		_    struct{} `skip:"10"`
+		Name string   `dSel:"tr td:nth-child(1)"`
+	}
+	_, err = NewFromString[All](rowsHTML)
+	assert.Error(t, err)
+}
+
+// TestNewFromString_Stride tests decoding a struct from several rows.
+func TestNewFromString_Stride(t *testing.T) {
+	t.Parallel()
+	type Game struct {
+		_       struct{} `stride:"2" skip:"1"`
+		Home    string   `dSel:"tr td.home"`
+		Away    string   `dSel:"tr td.away"`
+		Score   string   `dSel:"tr td.score"`
+		Summary string   `dSel:"tr td.detail" sub:"1"`
+		Meta    Meta
+	}
+	result, err := NewFromString[Game](`
+		<table>
+			<tr> <th>Home</th> <th>Away</th> <th>Score</th> </tr>
+			<tr> <td class="detail">Week 1</td> </tr>
+			<tr> <td class="home">Ames</td> <td class="away">Iowa</td> <td class="score">21-14</td> </tr>
+			<tr> <td class="detail" colspan="3">Ames rallied late.</td> </tr>
+			<tr> <td class="home">Ohio</td> <td class="away">Penn</td> <td class="score">10-3</td> </tr>
+			<tr> <td class="detail" colspan="3">A defensive game.</td> </tr>
+		</table>`)
+	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+	assert.Equal(t, "Ames", result[0].Home)
+	assert.Equal(t, "Iowa", result[0].Away)
+	assert.Equal(t, "21-14", result[0].Score)
+	assert.Equal(t, "Ames rallied late.", result[0].Summary)
+	assert.Equal(t, "Ohio", result[1].Home)
+	assert.Equal(t, "A defensive game.", result[1].Summary)
+	assert.Contains(t, result[1].Meta.HTML, "Penn")
+
+	type Invalid struct {
+		_    struct{} `stride:"2"`
+		Home string   `dSel:"tr td.home" sub:"2"`
+	}
+	_, err = NewFromString[Invalid](`<table><tr><td class="home">Ames</td></tr></table>`)
+	assert.ErrorContains(t, err, "invalid sub tag")
+}
+
+// TestNewFromString_RowSelector tests fields relative to row elements.
+func TestNewFromString_RowSelector(t *testing.T) {
+	t.Parallel()
+	type Card struct {
+		_     struct{} `rowSel:"div.card" skipSel:".ad"`
+		Name  string   `dSel:"h2"`
+		Price float64  `dSel:"span.price" default:"-1"`
+		Link  string   `dSel:"a" ctl:"url"`
+		Tags  int      `dSel:"ul" ctl:"count" qSel:"li"`
+		Meta  Meta
+	}
+	result, err := NewFromString[Card](`
+		<div class="grid">
+			<div class="card"> <h2>Kettle</h2> <span class="price">$24.50</span> <a href="/kettle">view</a> </div>
+			<div class="card ad"> <h2>Sponsored</h2> <a href="/ad">view</a> </div>
+			<div class="card"> <h2>Teapot</h2> <a href="/teapot">view</a> <ul><li>new</li><li>sale</li></ul> </div>
+		</div>`, WithBaseURL(&url.URL{Scheme: "https", Host: "shop.test"}))
+	assert.NoError(t, err)
+	if !assert.Len(t, result, 2) {
+		return
+	}
+	assert.Equal(t, "Kettle", result[0].Name)
+	assert.Equal(t, 24.5, result[0].Price)
+	assert.Equal(t, "https://shop.test/kettle", result[0].Link)
+	assert.Equal(t, "Teapot", result[1].Name)
+	assert.Equal(t, -1.0, result[1].Price)
+	assert.Equal(t, 2, result[1].Tags)
+	assert.Equal(t, "html > body > div:nth-child(1) > div:nth-child(3)", result[1].Meta.Path)
+
+	type Missing struct {
+		_    struct{} `rowSel:"div.card"`
+		Name string   `dSel:"h3"`
+	}
+	_, err = NewFromString[Missing](`<div class="card"><h2>Kettle</h2></div>`)
+	assert.ErrorContains(t, err, "not found for field Name")
+
+	type Nested struct {
+		_    struct{} `rowSel:"li.row"`
+		Name string   `dSel:"b"`
+		ID   string   `dSel:"i"`
+	}
+	nested, err := NewFromString[Nested](`
+		<ul>
+			<li class="row"> <i>1</i> <ul> <li class="row"> <i>2</i> <b>Shared</b> </li> </ul> </li>
+			<li class="row"> <i>3</i> <b>Own</b> </li>
+		</ul>`)
+	assert.NoError(t, err)
+	assert.Equal(t, []Nested{
+		{Name: "Shared", ID: "1"},
+		{Name: "Shared", ID: "2"},
+		{Name: "Own", ID: "3"},
+	}, nested)
+}
@@ -0,0 +1,249 @@
+package seltabl
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/PuerkitoBio/goquery"
+	"gopkg.in/yaml.v3"
+)
+
+// Row is a row decoded with a Schema, keyed by field name.
+type Row map[string]any
+
+// Schema describes the fields of a table at runtime instead of with the tags
+// of a struct.
+//
+// It is loaded with ParseSchema from JSON or YAML such as:
+//
+//	rows:
+//	  skipSel: tfoot
+//	fields:
+//	  - name: team
+//	    dSel: tr td:nth-child(1)
+//	  - name: wins
+//	    type: int
+//	    dSel: tr td:nth-child(2)
+//	    tags:
+//	      default: "0"
+type Schema struct {
+	// Fields are the fields of each row.
+	Fields []SchemaField `json:"fields" yaml:"fields"`
+	// Rows are the struct level tags configuring the rows, such as skip,
+	// skipSel, keepSel, stride and rowSel.
+	Rows map[string]string `json:"rows,omitempty" yaml:"rows,omitempty"`
+}
+
+// SchemaField is a field of a Schema.
+type SchemaField struct {
+	// Name is the key of the field in each Row.
+	Name string `json:"name" yaml:"name"`
+	// Type is the Go type of the field, such as "int" or "float64".
+	// Defaults to "string".
+	Type string `json:"type,omitempty" yaml:"type,omitempty"`
+	// DataSelector is the data selector (dSel) of the field.
+	DataSelector string `json:"dSel,omitempty" yaml:"dSel,omitempty"`
+	// HeadSelector is the header selector (hSel) of the field.
+	HeadSelector string `json:"hSel,omitempty" yaml:"hSel,omitempty"`
+	// QuerySelector is the query selector (qSel) of the field.
+	QuerySelector string `json:"qSel,omitempty" yaml:"qSel,omitempty"`
+	// ControlTag is the control (ctl) of the field.
+	ControlTag string `json:"ctl,omitempty" yaml:"ctl,omitempty"`
+	// Tags are any other tags of the field, such as fn, default or locale.
+	Tags map[string]string `json:"tags,omitempty" yaml:"tags,omitempty"`
+}
+
+// schemaTypes are the types usable as the type of a SchemaField by name.
+var schemaTypes = map[string]reflect.Type{
+	"string":            reflect.TypeOf(""),
+	"bool":              reflect.TypeOf(false),
+	"int":               reflect.TypeOf(int(0)),
+	"int8":              reflect.TypeOf(int8(0)),
+	"int16":             reflect.TypeOf(int16(0)),
+	"int32":             reflect.TypeOf(int32(0)),
+	"int64":             reflect.TypeOf(int64(0)),
+	"uint":              reflect.TypeOf(uint(0)),
+	"uint8":             reflect.TypeOf(uint8(0)),
+	"uint16":            reflect.TypeOf(uint16(0)),
+	"uint32":            reflect.TypeOf(uint32(0)),
+	"uint64":            reflect.TypeOf(uint64(0)),
+	"float32":           reflect.TypeOf(float32(0)),
+	"float64":           reflect.TypeOf(float64(0)),
+	"map[string]string": reflect.TypeOf(map[string]string{}),
+	"Meta":              metaType,
+}
+
+// ParseSchema parses a Schema from JSON or YAML.
+func ParseSchema(data []byte) (*Schema, error) {
+	var s Schema
+	err := yaml.Unmarshal(data, &s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse schema: %w", err)
+	}
+	_, err = s.structType()
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// Decode parses a goquery doc into rows of the schema.
+//
+// Fields are decoded as by New from a struct with the schema's fields and
+// tags.
+func (s *Schema) Decode(doc *goquery.Document, opts ...Option) ([]Row, error) {
+	return s.decode(doc, newOptions(opts))
+}
+
+// DecodeFromString parses a string into rows of the schema.
+func (s *Schema) DecodeFromString(htmlInput string, opts ...Option) ([]Row, error) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(htmlInput))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse html: %w", err)
+	}
+	return s.Decode(doc, opts...)
+}
+
+// DecodeFromURL parses a given URL's html into rows of the schema.
+func (s *Schema) DecodeFromURL(url string, opts ...Option) ([]Row, error) {
+	o := newOptions(opts)
+	doc, err := fetchDocument(o.ctx, o.client, url)
+	if err != nil {
+		return nil, err
+	}
+	o.fetchedAt = time.Now()
+	return s.decode(doc, o)
+}
+
+// decode parses a goquery doc into rows of the schema using the given
+// options.
+func (s *Schema) decode(doc *goquery.Document, o *options) ([]Row, error) {
+	dType, err := s.structType()
+	if err != nil {
+		return nil, err
+	}
+	results, err := decode(doc, dType, o)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]Row, results.Len())
+	for j := range rows {
+		rows[j] = make(Row, len(s.Fields))
+		for i, field := range s.Fields {
+			rows[j][field.Name] = results.Index(j).Field(i).Interface()
+		}
+	}
+	return rows, nil
+}
+
+// structType returns the struct type with the fields and tags of the schema.
+//
+// The ith field of the struct is the ith field of the schema, named after it
+// so that errors refer to it, followed by a blank field with the row tags if
+// there are any.
+func (s *Schema) structType() (reflect.Type, error) {
+	fields := make([]reflect.StructField, 0, len(s.Fields)+1)
+	seen := make(map[string]bool, len(s.Fields))
+	names := make(map[string]bool, len(s.Fields))
+	for i, field := range s.Fields {
+		if field.Name == "" {
+			return nil, fmt.Errorf("schema field %d has no name", i)
+		}
+		if seen[field.Name] {
+			return nil, fmt.Errorf("duplicate schema field %s", field.Name)
+		}
+		seen[field.Name] = true
+		typeName := field.Type
+		if typeName == "" {
+			typeName = "string"
+		}
+		typ, ok := schemaTypes[typeName]
+		if !ok {
+			return nil, fmt.Errorf(
+				"unsupported type %s for schema field %s",
+				field.Type,
+				field.Name,
+			)
+		}
+		tags := make(map[string]string, len(field.Tags)+4)
+		for key, value := range field.Tags {
+			tags[key] = value
+		}
+		for key, value := range map[string]string{
+			selectorDataTag:    field.DataSelector,
+			selectorHeaderTag:  field.HeadSelector,
+			selectorQueryTag:   field.QuerySelector,
+			selectorControlTag: field.ControlTag,
+		} {
+			if value != "" {
+				tags[key] = value
+			}
+		}
+		tag, err := structTag(tags)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tags for schema field %s: %w", field.Name, err)
+		}
+		name := exportedName(field.Name)
+		if name == "" || names[name] {
+			name = "F" + strconv.Itoa(i)
+		}
+		names[name] = true
+		fields = append(fields, reflect.StructField{
+			Name: name,
+			Type: typ,
+			Tag:  tag,
+		})
+	}
+	if len(s.Rows) > 0 {
+		tag, err := structTag(s.Rows)
+		if err != nil {
+			return nil, fmt.Errorf("invalid schema row tags: %w", err)
+		}
+		fields = append(fields, reflect.StructField{
+			Name:    "_",
+			PkgPath: metaType.PkgPath(),
+			Type:    reflect.TypeOf(struct{}{}),
+			Tag:     tag,
+		})
+	}
+	return reflect.StructOf(fields), nil
+}
+
+// structTag returns a struct tag with the given keys and values, sorted by
+// key.
+func structTag(tags map[string]string) (reflect.StructTag, error) {
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		if key == "" || strings.ContainsAny(key, " :\"\t\n") {
+			return "", fmt.Errorf("invalid tag key %q", key)
+		}
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	parts := make([]string, len(keys))
+	for i, key := range keys {
+		parts[i] = key + ":" + strconv.Quote(tags[key])
+	}
+	return reflect.StructTag(strings.Join(parts, " ")), nil
+}
+
+// exportedName returns the name as an exported Go identifier, or an empty
+// string if it is not an identifier.
+func exportedName(name string) string {
+	for i, r := range name {
+		if r != '_' && !unicode.IsLetter(r) && (i == 0 || !unicode.IsDigit(r)) {
+			return ""
+		}
+	}
+	r, size := utf8.DecodeRuneInString(name)
+	if r == '_' || !unicode.IsUpper(unicode.ToUpper(r)) {
+		return ""
+	}
+	return string(unicode.ToUpper(r)) + name[size:]
+}
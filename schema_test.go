@@ -0,0 +1,124 @@
+package seltabl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// schemaHTML is the html decoded by the schema tests.
+const schemaHTML = `
+	<table>
+		<tr> <th>Team</th> <th>Wins</th> <th>Site</th> </tr>
+		<tr> <td>Ames</td> <td>12</td> <td><a href="/ames">site</a></td> </tr>
+		<tr> <td>Iowa</td> <td></td> <td><a href="/iowa">site</a></td> </tr>
+		<tfoot> <tr> <td>Total</td> <td>12</td> <td></td> </tr> </tfoot>
+	</table>`
+
+// TestParseSchema tests decoding with schemas parsed from YAML and JSON.
+func TestParseSchema(t *testing.T) {
+	t.Parallel()
+	want := []Row{
+		{"team": "Ames", "wins": 12, "site": "/ames"},
+		{"team": "Iowa", "wins": -1, "site": "/iowa"},
+	}
+	tests := []struct {
+		name   string
+		schema string
+	}{
+		{
+			name: "yaml",
+			schema: `
+rows:
+  skipSel: tfoot
+fields:
+  - name: team
+    dSel: tr td:nth-child(1)
+  - name: wins
+    type: int
+    dSel: tr td:nth-child(2)
+    tags:
+      default: "-1"
+  - name: site
+    dSel: tr td:nth-child(3) a
+    ctl: query
+    qSel: href
+`,
+		},
+		{
+			name: "json",
+			schema: `{
+				"rows": {"skipSel": "tfoot"},
+				"fields": [
+					{"name": "team", "dSel": "tr td:nth-child(1)"},
+					{"name": "wins", "type": "int", "dSel": "tr td:nth-child(2)", "tags": {"default": "-1"}},
+					{"name": "site", "dSel": "tr td:nth-child(3) a", "ctl": "query", "qSel": "href"}
+				]
+			}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			schema, err := ParseSchema([]byte(tt.schema))
+			assert.NoError(t, err)
+			rows, err := schema.DecodeFromString(schemaHTML, WithAlignment(AlignByRow))
+			assert.NoError(t, err)
+			assert.Equal(t, want, rows)
+		})
+	}
+}
+
+// TestParseSchema_Errors tests invalid schemas.
+func TestParseSchema_Errors(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		schema  string
+		wantErr string
+	}{
+		{
+			name:    "unsupported type",
+			schema:  `{"fields": [{"name": "a", "type": "complex128"}]}`,
+			wantErr: "unsupported type complex128 for schema field a",
+		},
+		{
+			name:    "duplicate",
+			schema:  `{"fields": [{"name": "a"}, {"name": "a"}]}`,
+			wantErr: "duplicate schema field a",
+		},
+		{
+			name:    "no name",
+			schema:  `{"fields": [{"dSel": "td"}]}`,
+			wantErr: "schema field 0 has no name",
+		},
+		{
+			name:    "invalid tag",
+			schema:  `{"fields": [{"name": "a", "tags": {"bad key": "x"}}]}`,
+			wantErr: "invalid tag key",
+		},
+		{
+			name:    "invalid syntax",
+			schema:  `{"fields": [`,
+			wantErr: "failed to parse schema",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := ParseSchema([]byte(tt.schema))
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+// TestSchema_Decode tests errors of decoding refer to schema field names.
+func TestSchema_Decode(t *testing.T) {
+	t.Parallel()
+	schema := &Schema{Fields: []SchemaField{
+		{Name: "team", DataSelector: "tr td:nth-child(1)"},
+		{Name: "wins", Type: "int", DataSelector: "tr td:nth-child(1)"},
+	}}
+	_, err := schema.DecodeFromString(schemaHTML)
+	assert.ErrorContains(t, err, "failed to set field Wins")
+}
@@ -2,9 +2,12 @@ package seltabl
 
 import (
 	"fmt"
+	"net/url"
+	"strconv"
 	"strings"
 
 	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
 )
 
 // SelectorI is an interface for running a goquery selector on a cellValue
@@ -20,10 +23,12 @@ type SelectorI interface {
 //
 // It is a struct that satisfies the SelectorInferface interface.
 //
-// It contains a control tag and a query selector.
+// It contains a control tag, a query selector and the base url relative urls
+// are resolved against.
 type selector struct {
 	control string
 	query   string
+	base    *url.URL
 }
 
 // Select runs the selector on the cellValue and sets the cellText
@@ -42,10 +47,81 @@ func (s selector) Select(cellValue *goquery.Selection) (string, error) {
 			return "", fmt.Errorf("failed to find selector: %s", s.control)
 		}
 	case ctlAttrSelector:
-		cellText, exists = cellValue.Attr(s.query)
+		cellText, _, exists = attr(cellValue, s.query)
 		if !exists {
 			return "", fmt.Errorf("failed to find selector: %s", s.control)
 		}
+	case ctlURLSelector:
+		var name string
+		cellText, name, exists = attr(cellValue, s.query)
+		if !exists {
+			return "", fmt.Errorf("failed to find selector: %s", s.control)
+		}
+		var err error
+		if name == srcsetAttr {
+			cellText, err = resolveSrcset(s.base, cellText)
+		} else {
+			cellText, err = resolveURL(s.base, cellText)
+		}
+		if err != nil {
+			return "", err
+		}
+	case ctlSpacesSelector:
+		if cellValue.Length() == 0 {
+			return "", fmt.Errorf("failed to find selector: %s", s.control)
+		}
+		cellText = strings.Join(strings.Fields(cellValue.Text()), " ")
+	case ctlInnerHTMLSelector:
+		if cellValue.Length() == 0 {
+			return "", fmt.Errorf("failed to find selector: %s", s.control)
+		}
+		var err error
+		cellText, err = cellValue.Html()
+		if err != nil {
+			return "", fmt.Errorf("failed to render html: %w", err)
+		}
+	case ctlOuterHTMLSelector:
+		if cellValue.Length() == 0 {
+			return "", fmt.Errorf("failed to find selector: %s", s.control)
+		}
+		var err error
+		cellText, err = goquery.OuterHtml(cellValue.First())
+		if err != nil {
+			return "", fmt.Errorf("failed to render html: %w", err)
+		}
+	case ctlOwnTextSelector:
+		if cellValue.Length() == 0 {
+			return "", fmt.Errorf("failed to find selector: %s", s.control)
+		}
+		var builder strings.Builder
+		for _, node := range cellValue.First().Contents().Nodes {
+			if node.Type == html.TextNode {
+				builder.WriteString(node.Data)
+			}
+		}
+		cellText = strings.TrimSpace(builder.String())
+	case ctlCountSelector:
+		count := cellValue.Length()
+		if s.query != "" {
+			count = find(cellValue, s.query).Length()
+		}
+		cellText = strconv.Itoa(count)
+	case ctlRegexSelector:
+		re, err := compilePattern(s.query)
+		if err != nil {
+			return "", err
+		}
+		// no match selects an empty value, as with the regex transform,
+		// so that the default and required tags of the field apply.
+		match := re.FindStringSubmatch(cellValue.Text())
+		switch {
+		case match == nil:
+			cellText = ""
+		case len(match) > 1:
+			cellText = match[1]
+		default:
+			cellText = match[0]
+		}
 	default:
 		return "", fmt.Errorf(
 			"unsupported identifer: %s (identifers are %s)",
@@ -55,3 +131,16 @@ func (s selector) Select(cellValue *goquery.Selection) (string, error) {
 	}
 	return cellText, nil
 }
+
+// attr returns the value of the first attribute of the chain of attribute
+// names, such as "data-href || href", the cell has, its name, and whether
+// it has any of them.
+func attr(cellValue *goquery.Selection, names string) (string, string, bool) {
+	for _, name := range splitAlternatives(names) {
+		value, exists := cellValue.Attr(name)
+		if exists {
+			return value, name, true
+		}
+	}
+	return "", "", false
+}
@@ -277,3 +277,87 @@ func TestSelects(t *testing.T) {
 		assert.Equal(t, "", text)
 	}
 }
+
+// TestSelect_Controls tests the html, outerhtml, owntext, count, regex and
+// spaces controls.
+func TestSelect_Controls(t *testing.T) {
+	t.Parallel()
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(`
+		<table><tr><td id="cell">Rated  <b>good</b>
+			<i class="star"></i><i class="star"></i><i class="star"></i>
+			(3 of 5)</td></tr></table>`))
+	assert.NoError(t, err)
+	cell := doc.Find("#cell")
+	tests := []struct {
+		name     string
+		s        selector
+		want     string
+		contains bool
+		wantErr  bool
+	}{
+		{name: "spaces", s: selector{control: ctlSpacesSelector}, want: "Rated good (3 of 5)"},
+		{name: "html", s: selector{control: ctlInnerHTMLSelector}, want: "Rated  <b>good</b>", contains: true},
+		{name: "outerhtml", s: selector{control: ctlOuterHTMLSelector}, want: `<td id="cell">Rated  <b>good</b>`, contains: true},
+		{name: "owntext", s: selector{control: ctlOwnTextSelector}, want: "Rated  \n\t\t\t\n\t\t\t(3 of 5)"},
+		{name: "count", s: selector{control: ctlCountSelector, query: "i.star"}, want: "3"},
+		{name: "count cell", s: selector{control: ctlCountSelector}, want: "1"},
+		{name: "regex group", s: selector{control: ctlRegexSelector, query: `\((\d) of \d\)`}, want: "3"},
+		{name: "regex match", s: selector{control: ctlRegexSelector, query: `\d of \d`}, want: "3 of 5"},
+		{name: "regex no match", s: selector{control: ctlRegexSelector, query: `\d{3}`}, want: ""},
+		{name: "regex invalid", s: selector{control: ctlRegexSelector, query: `(`}, wantErr: true},
+		{name: "html empty", s: selector{control: ctlInnerHTMLSelector}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sel := cell
+			if tt.name == "html empty" {
+				sel = doc.Find("#missing")
+			}
+			got, err := tt.s.Select(sel)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			if tt.contains {
+				assert.Contains(t, got, tt.want)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// TestNewFromString_Controls tests that controls without a query selector
+// are kept by NewSelectorConfig.
+func TestNewFromString_Controls(t *testing.T) {
+	t.Parallel()
+	type Rating struct {
+		Stars int    `dSel:"tr td:nth-child(1)" ctl:"count" qSel:"i.star"`
+		Name  string `dSel:"tr td:nth-child(2)" ctl:"owntext"`
+		Note  string `dSel:"tr td:nth-child(2)" ctl:"html"`
+	}
+	result, err := NewFromString[Rating](`
+		<table><tr>
+			<td><i class="star"></i><i class="star"></i></td>
+			<td>Ames <small>IA</small></td>
+		</tr></table>`)
+	assert.NoError(t, err)
+	assert.Equal(t, []Rating{{Stars: 2, Name: "Ames", Note: "Ames <small>IA</small>"}}, result)
+}
+
+// TestNewFromString_RegexDefault tests that cells the regex control does not
+// match use the field's default.
+func TestNewFromString_RegexDefault(t *testing.T) {
+	t.Parallel()
+	type Score struct {
+		Points int `dSel:"tr td" ctl:"regex" qSel:"(\\d+) pts" default:"-1"`
+	}
+	result, err := NewFromString[Score](`
+		<table>
+			<tr><td>12 pts</td></tr>
+			<tr><td>DNF</td></tr>
+		</table>`)
+	assert.NoError(t, err)
+	assert.Equal(t, []Score{{Points: 12}, {Points: -1}}, result)
+}
@@ -1,11 +1,10 @@
 package seltabl
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
-	"strconv"
 	"strings"
-	"unicode"
 
 	"github.com/PuerkitoBio/goquery"
 )
@@ -21,9 +20,30 @@ func SetStructField[T any](
 	structField reflect.StructField,
 	cellValue *goquery.Selection,
 	selector SelectorI,
+) error {
+	return setStructField(
+		reflect.ValueOf(structPtr).Elem(),
+		structField,
+		cellValue,
+		selector,
+		NewSelectorConfig(structField.Tag),
+	)
+}
+
+// setStructField sets the field of the given struct value to the value
+// selected from the cell.
+//
+// It is used by SetStructField and by decode where the struct type is only
+// known at runtime. The cfg is the selector config of the field, as adjusted
+// for its dialect and chosen selectors.
+func setStructField(
+	v reflect.Value,
+	structField reflect.StructField,
+	cellValue *goquery.Selection,
+	selector SelectorI,
+	cfg *SelectorConfig,
 ) error {
 	var err error
-	v := reflect.ValueOf(structPtr).Elem()
 	field := v.FieldByName(structField.Name)
 	if !field.IsValid() {
 		return fmt.Errorf("no such field: %s in struct", structField.Name)
@@ -37,11 +57,54 @@ func SetStructField[T any](
 	if err != nil {
 		return fmt.Errorf("failed to run selector: %w", err)
 	}
+	value, err = applyTransforms(cfg.Transforms, value)
+	if err != nil {
+		return fmt.Errorf("failed to transform value: %w", err)
+	}
+	value, err = applyMapping(cfg, structField, value)
+	if err != nil {
+		return err
+	}
+	if cfg.HasDefault && strings.TrimSpace(value) == "" {
+		value = cfg.Default
+	}
+	err = validateValue(cfg, structField, value)
+	if err != nil {
+		return err
+	}
 	// setting the field's value
-	err = setFieldValue(fieldType, value, &field)
+	err = setFieldValue(fieldType, value, &field, cfg)
 	if err != nil {
 		return fmt.Errorf("failed to insert value: %w", err)
 	}
+	return validateRange(cfg, structField, field, value)
+}
+
+// setCell sets the field of the jth row to the value selected from the cell
+// by the selector of the field.
+func setCell(
+	results reflect.Value,
+	j int,
+	field reflect.StructField,
+	cell *goquery.Selection,
+	cfg *SelectorConfig,
+	selector SelectorI,
+) error {
+	err := setStructField(
+		results.Index(j),
+		field,    // name of the field to set
+		cell,     // goquery selection for cell
+		selector, // selector for the inner cell
+		cfg,      // selector config of the field
+	)
+	var validationErr ErrValidation
+	if errors.As(err, &validationErr) {
+		validationErr.Row = j
+		return validationErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to set field %s: %s", field.Name, err)
+	}
 	return nil
 }
 
@@ -51,243 +114,112 @@ func SetStructField[T any](
 // after selecting the value from a html node.
 //
 // It ensures that the type of the field is compatible with the type of the
-// value.
+// value. Numbers are parsed in the format of the field's locale tag.
 func setFieldValue(
 	fieldType reflect.Kind,
 	cellText string,
 	field *reflect.Value,
+	cfg *SelectorConfig,
 ) error {
 	switch fieldType {
 	case reflect.String:
 		field.SetString(cellText)
 		return nil
-	case reflect.Int:
-		if cellText == "" {
-			cellText = "0"
-		}
-		in, err := strconv.Atoi(cellText)
-		if err != nil {
-			in, err = strconv.Atoi(extractNumbers(cellText))
-			if err != nil {
-				return ErrParsing{
-					Field: field.Type(),
-					Value: cellText,
-					Err:   err,
-				}
-			}
-		}
-		field.SetInt(int64(in))
-		return nil
-	case reflect.Int8:
-		if cellText == "" {
-			cellText = "0"
-		}
-		in, err := strconv.Atoi(cellText)
-		if err != nil {
-			in, err = strconv.Atoi(extractNumbers(cellText))
-			if err != nil {
-				return ErrParsing{
-					Field: field.Type(),
-					Value: cellText,
-					Err:   err,
-				}
-			}
-		}
-		field.SetInt(int64(in))
-		return nil
-	case reflect.Int16:
-		if cellText == "" {
-			cellText = "0"
-		}
-		in, err := strconv.Atoi(cellText)
-		if err != nil {
-			in, err = strconv.Atoi(extractNumbers(cellText))
-			if err != nil {
-				return ErrParsing{
-					Field: field.Type(),
-					Value: cellText,
-					Err:   err,
-				}
-			}
-		}
-		field.SetInt(int64(in))
-		return nil
-	case reflect.Int32:
-		if cellText == "" {
-			cellText = "0"
-		}
-		in, err := strconv.Atoi(cellText)
-		if err != nil {
-			in, err = strconv.Atoi(extractNumbers(cellText))
-			if err != nil {
-				return ErrParsing{
-					Field: field.Type(),
-					Value: cellText,
-					Err:   err,
-				}
-			}
-		}
-		field.SetInt(int64(in))
-		return nil
-	case reflect.Int64:
-		if cellText == "" {
-			cellText = "0"
-		}
-		in, err := strconv.ParseInt(cellText, 10, 64)
-		if err != nil {
-			in, err = strconv.ParseInt(extractNumbers(cellText), 10, 64)
-			if err != nil {
-				return ErrParsing{
-					Field: field.Type(),
-					Value: cellText,
-					Err:   err,
-				}
-			}
-		}
-		field.SetInt(in)
-		return nil
-	case reflect.Uint:
-		if cellText == "" {
-			cellText = "0"
-		}
-		in, err := strconv.ParseUint(cellText, 10, 64)
-		if err != nil {
-			in, err = strconv.ParseUint(extractNumbers(cellText), 10, 64)
-			if err != nil {
-				return ErrParsing{
-					Field: field.Type(),
-					Value: cellText,
-					Err:   err,
-				}
-			}
-		}
-		field.SetUint(in)
-		return nil
-	case reflect.Uint8:
-		if cellText == "" {
-			cellText = "0"
-		}
-		in, err := strconv.ParseUint(cellText, 10, 64)
-		if err != nil {
-			in, err = strconv.ParseUint(extractNumbers(cellText), 10, 64)
-			if err != nil {
-				return ErrParsing{
-					Field: field.Type(),
-					Value: cellText,
-					Err:   err,
-				}
-			}
-		}
-		field.SetUint(in)
-		return nil
-	case reflect.Uint16:
-		if cellText == "" {
-			cellText = "0"
-		}
-		in, err := strconv.ParseUint(cellText, 10, 64)
+	case reflect.Bool:
+		b, err := parseBool(cfg, cellText)
 		if err != nil {
-			in, err = strconv.ParseUint(extractNumbers(cellText), 10, 64)
-			if err != nil {
-				return ErrParsing{
-					Field: field.Type(),
-					Value: cellText,
-					Err:   err,
-				}
+			return ErrParsing{
+				Field: field.Type(),
+				Value: cellText,
+				Err:   err,
 			}
 		}
-		field.SetUint(in)
+		field.SetBool(b)
 		return nil
-	case reflect.Uint32:
-		if cellText == "" {
-			cellText = "0"
-		}
-		in, err := strconv.ParseUint(cellText, 10, 64)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		format, err := lookupLocale(cfg.Locale)
 		if err != nil {
-			in, err = strconv.ParseUint(extractNumbers(cellText), 10, 64)
-			if err != nil {
-				return ErrParsing{
-					Field: field.Type(),
-					Value: cellText,
-					Err:   err,
-				}
-			}
+			return err
 		}
-		field.SetUint(in)
-		return nil
-	case reflect.Uint64:
-		if cellText == "" {
-			cellText = "0"
-		}
-		in, err := strconv.ParseUint(cellText, 10, 64)
+		err = setNumber(field, cellText, format)
 		if err != nil {
-			in, err = strconv.ParseUint(extractNumbers(cellText), 10, 64)
-			if err != nil {
-				return ErrParsing{
-					Field: field.Type(),
-					Value: cellText,
-					Err:   err,
-				}
+			return ErrParsing{
+				Field: field.Type(),
+				Value: cellText,
+				Err:   err,
 			}
 		}
-		field.SetUint(in)
-		return nil
-	case reflect.Float32:
-		if cellText == "" {
-			cellText = "0"
-		}
-		in, err := strconv.ParseFloat(cellText, 32)
-		if err != nil {
-			in, err = strconv.ParseFloat(extractFloatNumbers(cellText), 32)
-			if err != nil {
-				return ErrParsing{
-					Field: field.Type(),
-					Value: cellText,
-					Err:   err,
-				}
-			}
-		}
-		field.SetFloat(in)
-		return nil
-	case reflect.Float64:
-		if cellText == "" {
-			cellText = "0"
-		}
-		in, err := strconv.ParseFloat(cellText, 64)
-		if err != nil {
-			in, err = strconv.ParseFloat(extractFloatNumbers(cellText), 64)
-			if err != nil {
-				return ErrParsing{
-					Field: field.Type(),
-					Value: cellText,
-					Err:   err,
-				}
-			}
-		}
-		field.SetFloat(in)
 		return nil
 	default:
 		return fmt.Errorf("unsupported type: %s", fieldType)
 	}
 }
 
-// extractNumbers extracts all numbers from a string.
-func extractNumbers(input string) string {
-	var builder strings.Builder
-	for _, char := range input {
-		if unicode.IsDigit(char) {
-			builder.WriteRune(char)
+// setMissingCells sets the fields of the rows their data selector did not
+// select a cell in to their default, and checks them against their
+// validation tags as empty cells.
+//
+// Fields filling down or following links are not validated, as their
+// missing cells are filled or left empty afterwards.
+func setMissingCells(results reflect.Value, matches []*fieldMatch) error {
+	dType := results.Type().Elem()
+	for _, m := range matches {
+		validate := m.cfg.Fill != fillDown && m.cfg.Follow == "" &&
+			hasValidation(m.cfg)
+		if !m.cfg.HasDefault && !validate {
+			continue
+		}
+		selected := make(map[int]bool, len(m.positions))
+		for _, position := range m.positions {
+			selected[position] = true
+		}
+		for j := 0; j < results.Len(); j++ {
+			if selected[j] {
+				continue
+			}
+			err := setMissingCell(results, j, dType.Field(m.index), m.cfg, validate)
+			if err != nil {
+				return err
+			}
 		}
 	}
-	return builder.String()
+	return nil
 }
 
-// extractFloatNumbers extracts all numbers from a string including any decimal points.
-func extractFloatNumbers(input string) string {
-	var builder strings.Builder
-	for _, char := range input {
-		if unicode.IsDigit(char) || char == '.' {
-			builder.WriteRune(char)
+// setMissingCell sets the field of the jth row, whose data selector did not
+// select a cell, to its default if it has one and, if validate is set,
+// checks it against the field's validation tags.
+func setMissingCell(
+	results reflect.Value,
+	j int,
+	field reflect.StructField,
+	cfg *SelectorConfig,
+	validate bool,
+) error {
+	value := results.Index(j).FieldByIndex(field.Index)
+	if cfg.HasDefault {
+		err := setFieldValue(value.Kind(), cfg.Default, &value, cfg)
+		if err != nil {
+			return fmt.Errorf(
+				"failed to set default of field %s: %w",
+				field.Name,
+				err,
+			)
 		}
 	}
-	return builder.String()
+	if !validate {
+		return nil
+	}
+	err := validateValue(cfg, field, cfg.Default)
+	if err == nil {
+		err = validateRange(cfg, field, value, cfg.Default)
+	}
+	var validationErr ErrValidation
+	if errors.As(err, &validationErr) {
+		validationErr.Row = j
+		return validationErr
+	}
+	return err
 }
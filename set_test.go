@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/PuerkitoBio/goquery"
+	"github.com/stretchr/testify/assert"
 )
 
 // TestStruct is a test struct
@@ -357,3 +358,43 @@ func TestSetStructField(t *testing.T) {
 		})
 	}
 }
+
+// TestNewFromString_Default tests the default tag for empty and missing
+// cells.
+func TestNewFromString_Default(t *testing.T) {
+	t.Parallel()
+	type Player struct {
+		Name   string  `dSel:"tr td:nth-child(1)"`
+		Number int     `dSel:"tr td:nth-child(2)" default:"-1"`
+		Note   string  `dSel:"tr td:nth-child(3)" default:"n/a"`
+		Avg    float64 `dSel:"tr td.avg" default:"0.5"`
+		Pos    string  `dSel:"tr td.pos" map:"P=pitcher" default:"fielder"`
+	}
+	result, err := NewFromString[Player](`
+		<table>
+			<tr> <td>Ruth</td> <td>3</td> <td>bat</td> <td class="avg">0.342</td> <td class="pos">P</td> </tr>
+			<tr> <td>Gehrig</td> <td></td> <td> </td> <td class="avg">0.340</td> <td class="pos"></td> </tr>
+			<tr> <td>Cobb</td> <td>24</td> </tr>
+		</table>`)
+	assert.NoError(t, err)
+	assert.Equal(t, []Player{
+		{Name: "Ruth", Number: 3, Note: "bat", Avg: 0.342, Pos: "pitcher"},
+		{Name: "Gehrig", Number: -1, Note: "n/a", Avg: 0.340, Pos: "fielder"},
+		{Name: "Cobb", Number: 24, Note: "n/a", Avg: 0.5, Pos: "fielder"},
+	}, result)
+
+	type Missing struct {
+		Name string `dSel:"tr td:nth-child(1)"`
+		Team string `dSel:"tr td.team" default:"free agent"`
+	}
+	missing, err := NewFromString[Missing](`<table><tr><td>Ruth</td></tr></table>`)
+	assert.NoError(t, err)
+	assert.Equal(t, []Missing{{Name: "Ruth", Team: "free agent"}}, missing)
+
+	type Invalid struct {
+		Name   string `dSel:"tr td:nth-child(1)"`
+		Number int    `dSel:"tr td.number" default:"none"`
+	}
+	_, err = NewFromString[Invalid](`<table><tr><td>Ruth</td></tr></table>`)
+	assert.ErrorContains(t, err, "failed to set default of field Number")
+}
@@ -1,14 +1,16 @@
 package seltabl
 
 import (
-	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"net/http"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
 )
 
 // New parses a goquery doc into a slice of structs.
@@ -27,6 +29,85 @@ import (
 //     the cell.
 //   - control selector (cSel): used to control what to query for the inner
 //     text or attribute of the cell.
+//   - follow (follow): used to follow a link in the cell, such as "a@href",
+//     and decode the linked page into the struct, pointer or slice field.
+//     Linked pages without rows or matching selectors and links back to a
+//     page being followed leave the field with its zero value.
+//   - transforms (fn): comma separated transforms applied to the selected
+//     value before it is validated and converted, such as
+//     "strip-footnotes,collapse-whitespace".
+//   - locale (locale): locale numbers in the cell are written in, such as
+//     "de" for "1.234,56". Defaults to english.
+//   - map (map): comma separated key=value entries mapping the cell onto
+//     another value, such as "W=win,L=loss,T=tie". Unknown values fail with
+//     an ErrValidation.
+//   - true and false (true, false): space separated values of a bool field
+//     that are true or false, such as "yes" and "no".
+//   - default (default): value used when the cell is empty, or when the
+//     data selector matches fewer rows than those of other fields.
+//   - fill (fill): "down" fills an empty or missing cell with the closest
+//     non-empty cell above it, such as for cells spanning several rows.
+//     Structs with a field filling down are aligned by row element.
+//   - group (group): selector of group cells, such as section header rows,
+//     used instead of the data selector. The field of each row is selected
+//     from the last group cell before the row.
+//   - pivot (pivot): "key" or "value" to unpivot columns, such as one per
+//     year, into a row per column. The key field is selected from the header
+//     cells matched by its header selector, and the value field from the
+//     cells in the same column matched by its data selector.
+//   - dialect (dialect): "xpath" to write the header, data and count query
+//     selectors of the field in XPath instead of css.
+//
+// Selectors prefixed with "xpath:" are XPath expressions, such as
+// "xpath://th[text()='Total']/following-sibling::td", whatever the dialect.
+//
+// Header, data and query selectors may be chains of alternatives separated
+// by "||", such as "table#stats td || table.wikitable td". The first
+// alternative matching any elements wins, and for the query and url controls
+// the first attribute the cell has, such as "data-href || href". The
+// alternative chosen from a header or data selector is reported to the
+// function set by WithSelectorChoices and in Meta. With a rowSel tag the
+// alternative is chosen within the row elements and used for every row.
+//
+// A field of type map[string]T with selectors collects the columns not
+// selected by other fields, keyed by header text. Its header selector selects
+// the header cells of every column, and its data selector their cells. Maps
+// without selectors are ignored.
+//
+// Numeric fields accept signs, accounting parentheses, thousands
+// separators, currency and percent signs, scientific notation and SI
+// suffixes such as "1.5k", and fail with an ErrParsing if the number does
+// not fit in the field.
+//
+// Fields of type Meta are filled with the provenance of each row.
+//
+// The tags of blank fields configure the rows of the struct for every
+// field:
+//
+//   - skip: number of rows skipped at the start.
+//   - skipSel: rows matching, or within an element matching, the selector
+//     are skipped, such as "tr.subtotal, tfoot".
+//   - keepSel: only rows matching, or within an element matching, the
+//     selector are kept.
+//   - stride: number of rows decoded into each struct, such as 2 for a
+//     summary row followed by a detail row. Cells are aligned by row element
+//     and the sub tag of a field, counting from 0, selects which of the rows
+//     its cells are in.
+//   - rowSel: selector of the row elements, such as "div.card" or "ul > li",
+//     for sequences of elements other than table rows. The data selectors of
+//     the fields are relative to each row element and select the first
+//     matching element in it. Rows without one use the field's default.
+//
+// Validation tags are checked while decoding and fail with an ErrValidation:
+//
+//   - must: raw text that must be present in the document.
+//   - required: whether the cell must not be empty.
+//   - pattern: regular expression the cell must match.
+//   - min and max: bounds of a number, or of the length of a string.
+//   - oneof: space separated values the cell must be one of.
+//
+// Rows missing the cell of a field are checked as if the cell were empty,
+// after its default is applied.
 //
 // Example:
 //
@@ -56,53 +137,159 @@ import (
 //			fmt.Printf("pp %+v\n", pp)
 //		}
 //	}
-func New[T any](doc *goquery.Document) ([]T, error) {
+func New[T any](doc *goquery.Document, opts ...Option) ([]T, error) {
+	return newWithOptions[T](doc, newOptions(opts))
+}
+
+// newWithOptions parses a goquery doc into a slice of structs using the
+// given options.
+func newWithOptions[T any](doc *goquery.Document, o *options) ([]T, error) {
 	dType := reflect.TypeOf((*T)(nil)).Elem()
 	if dType.Kind() != reflect.Struct && dType.Kind() != reflect.Ptr {
 		return nil, fmt.Errorf("expected struct, got %s", dType.Kind())
 	}
-	results := make([]T, 0)
-	var cfg *SelectorConfig
-	for i := 0; i < dType.NumField(); i++ {
-		cfg = NewSelectorConfig(dType.Field(i).Tag)
-		if cfg.DataSelector == "" {
-			continue
+	results, err := decode(doc, dType, o)
+	if err != nil {
+		return nil, err
+	}
+	return results.Interface().([]T), nil
+}
+
+// decode parses a goquery doc into a slice of the given struct type.
+//
+// It is used by New and by fields with a follow tag to decode linked pages
+// into types only known at runtime.
+func decode(
+	doc *goquery.Document,
+	dType reflect.Type,
+	o *options,
+) (reflect.Value, error) {
+	results := reflect.MakeSlice(reflect.SliceOf(dType), 0, 0)
+	base := documentBase(doc, o.baseURL)
+	err := validateDocument(doc, dType)
+	if err != nil {
+		return results, err
+	}
+	rc, err := newRowConfig(dType)
+	if err != nil {
+		return results, err
+	}
+	var order map[*html.Node]int
+	if alignsByRow(dType, rc, o) || hasGroups(dType) {
+		order = documentOrder(doc)
+	}
+	pivot, err := matchPivot(doc, dType)
+	if err != nil {
+		return results, err
+	}
+	maps, err := matchColumns(doc, dType)
+	if err != nil {
+		return results, err
+	}
+	var rows []*goquery.Selection
+	var matches []*fieldMatch
+	var selectors map[string]string
+	switch {
+	case o.rows != nil:
+		rows, rc = o.rows, &rowConfig{Stride: 1}
+		selectors = chooseSelectors(rowScope(doc, rows), dType, o)
+		matches, err = matchCells(doc, dType, rows, selectors)
+		if err != nil {
+			return results, err
 		}
-		dataRows := doc.Find(cfg.DataSelector)
-		if dataRows.Length() <= 0 {
-			return nil, ErrSelectorNotFound{
-				Typ:   dType,
-				Field: dType.Field(i),
-				Cfg:   cfg,
-			}
+	case rc.RowSelector != "":
+		rows = selectRows(doc, rc)
+		selectors = chooseSelectors(rowScope(doc, rows), dType, o)
+		matches, err = matchCells(doc, dType, rows, selectors)
+		if err != nil {
+			return results, err
 		}
-		if cfg.HeadSelector != "" && cfg.HeadSelector != "-" {
-			_ = dataRows.RemoveFiltered(cfg.HeadSelector)
+	default:
+		selectors = chooseSelectors(doc.Selection, dType, o)
+		matches, err = matchFields(doc, dType)
+		if err != nil {
+			return results, err
 		}
-		if len(results) < dataRows.Length() {
-			results = make([]T, dataRows.Length())
+		filterCells(matches, rc)
+		rows, err = alignRows(dType, matches, order, rc, o)
+		if err != nil {
+			return results, err
 		}
-		for j := 0; j < dataRows.Length(); j++ {
-			err := SetStructField(
-				&results[j],
-				dType.Field(i), // name of the field to set
-				dataRows.Eq(j), // goquery selection for cell
-				&selector{
-					control: cfg.ControlTag,
-					query:   cfg.QuerySelector,
-				}, // selector for the inner cell
-			)
+	}
+	rows = skipRows(rows, matches, rc)
+	rows, err = strideRows(rows, matches, rc)
+	if err != nil {
+		return results, err
+	}
+	if len(rows) < 1 {
+		return results, errNoDataFound
+	}
+	results = reflect.MakeSlice(results.Type(), len(rows), len(rows))
+	for _, m := range matches {
+		field := dType.Field(m.index)
+		switch {
+		case m.cfg.Follow != "":
+			err := followField(base, results, field, m.cells, m.positions, m.cfg, o)
 			if err != nil {
-				return nil, fmt.Errorf(
-					"failed to set field %s: %s",
-					dType.Field(i).Name,
+				return results, fmt.Errorf(
+					"failed to follow field %s: %w",
+					field.Name,
 					err,
 				)
 			}
+		case m.cfg.Fill == fillDown:
+		case m.cfg.Fill != "":
+			return results, fmt.Errorf(
+				"unsupported fill %s for field %s",
+				m.cfg.Fill,
+				field.Name,
+			)
+		default:
+			selector := newSelector(m.cfg, base)
+			for j := 0; j < m.cells.Length(); j++ {
+				err := setCell(results, m.positions[j], field, m.cells.Eq(j), m.cfg, selector)
+				if err != nil {
+					return results, err
+				}
+			}
+		}
+	}
+	err = setMissingCells(results, matches)
+	if err != nil {
+		return results, err
+	}
+	for _, m := range matches {
+		if m.cfg.Fill != fillDown || m.cfg.Follow != "" {
+			continue
+		}
+		err = fillDownField(results, m, base)
+		if err != nil {
+			return results, err
 		}
 	}
-	if len(results) < 1 {
-		return nil, fmt.Errorf("no data found")
+	err = setGroups(doc, results, rows, order, base)
+	if err != nil {
+		return results, err
+	}
+	bound := boundColumns(matches, pivot)
+	for _, c := range maps {
+		err = c.setColumns(results, rows, bound, base)
+		if err != nil {
+			return results, err
+		}
+	}
+	if pivot != nil {
+		results, rows, err = pivot.unpivot(results, rows, base)
+		if err != nil {
+			return results, err
+		}
+		if len(rows) < 1 {
+			return results, errNoDataFound
+		}
+	}
+	err = setMeta(results, rows, documentURL(doc, o.baseURL), o.fetchedAt, selectors)
+	if err != nil {
+		return results, err
 	}
 	return results, nil
 }
@@ -154,7 +341,7 @@ func New[T any](doc *goquery.Document) ([]T, error) {
 //			fmt.Printf("pp %+v\n", pp)
 //		}
 //	}
-func NewFromString[T any](htmlInput string) ([]T, error) {
+func NewFromString[T any](htmlInput string, opts ...Option) ([]T, error) {
 	doc, err := goquery.NewDocumentFromReader(
 		strings.NewReader(htmlInput),
 	)
@@ -165,7 +352,7 @@ func NewFromString[T any](htmlInput string) ([]T, error) {
 				err,
 			)
 	}
-	return New[T](doc)
+	return New[T](doc, opts...)
 }
 
 // NewFromReader parses a reader into a slice of structs.
@@ -217,12 +404,12 @@ func NewFromString[T any](htmlInput string) ([]T, error) {
 //			fmt.Printf("pp %+v\n", pp)
 //		}
 //	}
-func NewFromReader[T any](r io.Reader) ([]T, error) {
+func NewFromReader[T any](r io.Reader, opts ...Option) ([]T, error) {
 	doc, err := goquery.NewDocumentFromReader(r)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse html: %w", err)
 	}
-	return New[T](doc)
+	return New[T](doc, opts...)
 }
 
 // NewFromURL parses a given URL's html into a slice of structs adhering to the
@@ -267,24 +454,14 @@ func NewFromReader[T any](r io.Reader) ([]T, error) {
 //			fmt.Printf("pp %+v\n", pp)
 //		}
 //	}
-func NewFromURL[T any](url string) ([]T, error) {
-	client := &http.Client{}
-	resp, err := client.Get(url)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get url: %w", err)
-	}
-	defer resp.Body.Close()
-	body, err := io.ReadAll(resp.Body)
+func NewFromURL[T any](url string, opts ...Option) ([]T, error) {
+	o := newOptions(opts)
+	doc, err := fetchDocument(o.ctx, o.client, url)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read body: %w", err)
+		return nil, err
 	}
-	doc, err := goquery.NewDocumentFromReader(
-		strings.NewReader(string(body)),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse html: %w", err)
-	}
-	return New[T](doc)
+	o.fetchedAt = time.Now()
+	return newWithOptions[T](doc, o)
 }
 
 // NewFromBytes parses a byte slice into a slice of structs adhering to the
@@ -337,14 +514,14 @@ func NewFromURL[T any](url string) ([]T, error) {
 //			fmt.Printf("pp %+v\n", pp)
 //		}
 //	}
-func NewFromBytes[T any](b []byte) ([]T, error) {
+func NewFromBytes[T any](b []byte, opts ...Option) ([]T, error) {
 	doc, err := goquery.NewDocumentFromReader(
 		strings.NewReader(string(b)),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse html: %w", err)
 	}
-	return New[T](doc)
+	return New[T](doc, opts...)
 }
 
 // NewCh parses a goquery doc into a slice of structs delivered to a channel.
@@ -360,11 +537,12 @@ func NewCh[T any](doc *goquery.Document, ch chan T) error {
 		return fmt.Errorf("expected struct, got %s", dType.Kind())
 	}
 	results := make([]T, 0)
+	base := documentBase(doc, nil)
 	var cfg *SelectorConfig
 	var dataRows *goquery.Selection
 	var err error
 	cfg = NewSelectorConfig(dType.Field(0).Tag)
-	dRows := doc.Find(cfg.DataSelector)
+	dRows := find(doc.Selection, cfg.DataSelector)
 	for i := 0; i < dRows.Length(); i++ {
 		if len(results) < dRows.Length() {
 			results = make([]T, dRows.Length())
@@ -376,18 +554,15 @@ func NewCh[T any](doc *goquery.Document, ch chan T) error {
 			if cfg.DataSelector == "" {
 				continue
 			}
-			dataRows = doc.Find(cfg.DataSelector).Eq(i)
+			dataRows = find(doc.Selection, cfg.DataSelector).Eq(i)
 			if cfg.HeadSelector != "" && cfg.HeadSelector != "-" {
-				_ = dataRows.RemoveFiltered(cfg.HeadSelector)
+				removeFiltered(dataRows, cfg.HeadSelector)
 			}
 			err = SetStructField(
 				&results[i],
-				dType.Field(j), // name of the field to set
-				dataRows,       // goquery selection for cell
-				&selector{
-					control: cfg.ControlTag,
-					query:   cfg.QuerySelector,
-				}, // selector for the inner cell
+				dType.Field(j),         // name of the field to set
+				dataRows,               // goquery selection for cell
+				newSelector(cfg, base), // selector for the inner cell
 			)
 			if err != nil {
 				return fmt.Errorf(
@@ -485,19 +660,9 @@ func NewFromBytesCh[T any](b []byte, ch chan T) error {
 //		}
 //	}
 func NewFromURLCh[T any](url string, ch chan T) error {
-	client := &http.Client{}
-	resp, err := client.Get(url)
-	if err != nil {
-		return fmt.Errorf("failed to get url: %w", err)
-	}
-	defer resp.Body.Close()
-	body, err := io.ReadAll(resp.Body)
+	doc, err := fetchDocument(context.Background(), &http.Client{}, url)
 	if err != nil {
-		return fmt.Errorf("failed to read body: %w", err)
-	}
-	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
-	if err != nil {
-		return fmt.Errorf("failed to parse html: %w", err)
+		return err
 	}
 	return NewCh(doc, ch)
 }
@@ -518,9 +683,10 @@ func NewChFn[
 		return fmt.Errorf("expected struct, got %s", dType.Kind())
 	}
 	results := make([]T, 0)
+	base := documentBase(doc, nil)
 	var cfg *SelectorConfig
 	cfg = NewSelectorConfig(dType.Field(0).Tag)
-	dRows := doc.Find(cfg.DataSelector)
+	dRows := find(doc.Selection, cfg.DataSelector)
 	var dataRows *goquery.Selection
 	var err error
 	for i := 0; i < dRows.Length(); i++ {
@@ -532,18 +698,15 @@ func NewChFn[
 			if cfg.DataSelector == "" {
 				continue
 			}
-			dataRows = doc.Find(cfg.DataSelector).Eq(i)
+			dataRows = find(doc.Selection, cfg.DataSelector).Eq(i)
 			if cfg.HeadSelector != "" && cfg.HeadSelector != "-" {
-				_ = dataRows.RemoveFiltered(cfg.HeadSelector)
+				removeFiltered(dataRows, cfg.HeadSelector)
 			}
 			err = SetStructField(
 				&results[i],
-				dType.Field(j), // name of the field to set
-				dataRows,       // goquery selection for cell
-				&selector{
-					control: cfg.ControlTag,
-					query:   cfg.QuerySelector,
-				}, // selector for the inner cell
+				dType.Field(j),         // name of the field to set
+				dataRows,               // goquery selection for cell
+				newSelector(cfg, base), // selector for the inner cell
 			)
 			if err != nil {
 				return fmt.Errorf(
@@ -634,11 +797,12 @@ func NewChFnErr[
 		return fmt.Errorf("expected struct, got %s", dType.Kind())
 	}
 	results := make([]T, 0)
+	base := documentBase(doc, nil)
 	var cfg *SelectorConfig
 	var dataRows *goquery.Selection
 	var err error
 	cfg = NewSelectorConfig(dType.Field(0).Tag)
-	dRows := doc.Find(cfg.DataSelector)
+	dRows := find(doc.Selection, cfg.DataSelector)
 	for i := 0; i < dRows.Length(); i++ {
 		if len(results) < dRows.Length() {
 			results = make([]T, dRows.Length())
@@ -648,18 +812,15 @@ func NewChFnErr[
 			if cfg.DataSelector == "" {
 				continue
 			}
-			dataRows = doc.Find(cfg.DataSelector).Eq(i)
+			dataRows = find(doc.Selection, cfg.DataSelector).Eq(i)
 			if cfg.HeadSelector != "" && cfg.HeadSelector != "-" {
-				_ = dataRows.RemoveFiltered(cfg.HeadSelector)
+				removeFiltered(dataRows, cfg.HeadSelector)
 			}
 			err = SetStructField(
 				&results[i],
-				dType.Field(j), // name of the field to set
-				dataRows,       // goquery selection for cell
-				&selector{
-					control: cfg.ControlTag,
-					query:   cfg.QuerySelector,
-				}, // selector for the inner cell
+				dType.Field(j),         // name of the field to set
+				dataRows,               // goquery selection for cell
+				newSelector(cfg, base), // selector for the inner cell
 			)
 			if err != nil {
 				break
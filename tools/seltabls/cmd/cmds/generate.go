@@ -0,0 +1,277 @@
+package cmds
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"go/format"
+	"go/token"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/conneroisu/seltabl"
+	"github.com/conneroisu/seltabl/tools/seltabls/pkg/parsers"
+	"github.com/spf13/cobra"
+)
+
+// NewGenerateCmd returns the generate command which writes a struct for a
+// table of a page along with a test decoding a fixture of the page.
+func NewGenerateCmd(ctx context.Context, w io.Writer) *cobra.Command {
+	var (
+		selector   string
+		index      int
+		pkgName    string
+		structName string
+		fileName   string
+	)
+	cmd := &cobra.Command{
+		Use:   "generate <url or file>",
+		Short: "Generate a seltabl struct for a table of a page.",
+		Long: `
+Generate a seltabl struct for a table of a page.
+
+The page is fetched from a url or read from a local html file, and the table
+is chosen by selector or by its index among the tables of the page. The struct
+is written to <file>.go with a test decoding a fixture of the page written to
+<file>.html and <file>_test.go.
+`,
+		Args: cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, _ []string) error {
+			cmd.SetOut(w)
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := parsers.IsValidPackageName(pkgName); err != nil {
+				return err
+			}
+			if err := parsers.IsValidFileName(fileName); err != nil {
+				return err
+			}
+			if !token.IsIdentifier(structName) {
+				return fmt.Errorf(
+					"struct name %q is not a valid go identifier",
+					structName,
+				)
+			}
+			source := args[0]
+			content, err := readPage(ctx, source)
+			if err != nil {
+				return err
+			}
+			doc, err := goquery.NewDocumentFromReader(bytes.NewReader(content))
+			if err != nil {
+				return fmt.Errorf("failed to parse html: %w", err)
+			}
+			table, err := findTable(doc, selector, index)
+			if err != nil {
+				return err
+			}
+			gen := generateTemplate{
+				PackageName: pkgName,
+				StructName:  structName,
+				FileName:    fileName,
+				Table:       table,
+				Fields:      seltabl.Infer(table),
+			}
+			if u, err := url.Parse(source); err == nil && u.Scheme != "" {
+				gen.URL = source
+			}
+			files := map[string]*template.Template{
+				fileName + ".go":      structTemplate,
+				fileName + "_test.go": testTemplate,
+			}
+			for name, tmpl := range files {
+				src, err := executeGoTemplate(tmpl, gen)
+				if err != nil {
+					return err
+				}
+				err = os.WriteFile(name, src, 0644)
+				if err != nil {
+					return fmt.Errorf("failed to write file: %w", err)
+				}
+				cmd.Println("wrote file:", name)
+			}
+			err = os.WriteFile(fileName+".html", content, 0644)
+			if err != nil {
+				return fmt.Errorf("failed to write file: %w", err)
+			}
+			cmd.Println("wrote file:", fileName+".html")
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(
+		&selector,
+		"selector",
+		"s",
+		"",
+		"selector of the table, overriding the index",
+	)
+	cmd.Flags().IntVarP(
+		&index,
+		"index",
+		"i",
+		0,
+		"index of the table among the tables of the page",
+	)
+	cmd.Flags().StringVarP(
+		&pkgName,
+		"package-name",
+		"p",
+		"main",
+		"package name to use",
+	)
+	cmd.Flags().StringVarP(
+		&structName,
+		"struct-name",
+		"n",
+		"TableStruct",
+		"name of the generated struct",
+	)
+	cmd.Flags().StringVarP(
+		&fileName,
+		"file-name",
+		"f",
+		"table",
+		"file name to write (without .go extension or _test.go) ex: koala",
+	)
+	return cmd
+}
+
+// readPage returns the html of the page at the url, or of the local file if
+// the source is not a url.
+func readPage(ctx context.Context, source string) ([]byte, error) {
+	u, err := url.Parse(source)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		content, err := os.ReadFile(filepath.Clean(source))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file: %w", err)
+		}
+		return content, nil
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, source, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get url: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf(
+			"received non-200 response code: %d",
+			resp.StatusCode,
+		)
+	}
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read body: %w", err)
+	}
+	return content, nil
+}
+
+// findTable returns the discovered table matching the selector, or the
+// table at the index if the selector is empty.
+func findTable(
+	doc *goquery.Document,
+	selector string,
+	index int,
+) (seltabl.TableInfo, error) {
+	tables := seltabl.Discover(doc)
+	if selector == "" {
+		if index < 0 || index >= len(tables) {
+			return seltabl.TableInfo{}, fmt.Errorf(
+				"table index %d out of range: found %d tables",
+				index,
+				len(tables),
+			)
+		}
+		return tables[index], nil
+	}
+	for _, table := range tables {
+		if doc.Find(table.Selector).Is(selector) {
+			return table, nil
+		}
+	}
+	return seltabl.TableInfo{}, fmt.Errorf("no table matches selector %s", selector)
+}
+
+// generateTemplate is the data of the generated struct and test files.
+type generateTemplate struct {
+	PackageName string
+	StructName  string
+	FileName    string
+	URL         string
+	Table       seltabl.TableInfo
+	Fields      []seltabl.FieldInfo
+}
+
+// executeGoTemplate executes the template and formats the result as Go
+// source.
+func executeGoTemplate(tmpl *template.Template, data any) ([]byte, error) {
+	var buf bytes.Buffer
+	err := tmpl.Execute(&buf, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute template: %w", err)
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format source: %w", err)
+	}
+	return src, nil
+}
+
+var (
+	structTemplate = template.Must(template.New("struct").Funcs(template.FuncMap{
+		"comment": func(s string) string {
+			return strings.Join(strings.Fields(s), " ")
+		},
+	}).Parse(structTemplateString))
+	testTemplate = template.Must(template.New("test").Parse(generateTestTemplateString))
+)
+
+// structTemplateString is a string for a generated struct file template.
+const structTemplateString = `package {{.PackageName}}
+
+// {{.StructName}} is a row of the table
+{{- with .Table.Caption}} "{{comment .}}"{{end}}
+{{- with .Table.Heading}} under the heading "{{comment .}}"{{end}}.
+{{- with .URL}}
+//
+// @url: {{.}}
+{{- end}}
+type {{.StructName}} struct {
+{{- range .Fields}}
+	{{.Name}} {{.Type}} ` + "`{{.Tag}}`" + `
+{{- end}}
+}
+`
+
+// generateTestTemplateString is a string for a generated test file template.
+const generateTestTemplateString = `package {{.PackageName}}
+
+import (
+	_ "embed"
+	"testing"
+
+	"github.com/conneroisu/seltabl"
+	"github.com/stretchr/testify/assert"
+)
+
+//go:embed {{.FileName}}.html
+var {{.StructName}}Fixture string
+
+// Test{{.StructName}} tests decoding {{.StructName}} from its fixture.
+func Test{{.StructName}}(t *testing.T) {
+	t.Parallel()
+	rows, err := seltabl.NewFromString[{{.StructName}}]({{.StructName}}Fixture)
+	assert.NoError(t, err)
+	assert.Len(t, rows, {{.Table.Rows}})
+}
+`
@@ -0,0 +1,112 @@
+package cmds
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	fixture, err := filepath.Abs(filepath.Join("testdata", "generate.html"))
+	if err != nil {
+		t.Fatalf("failed to resolve fixture: %v", err)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get working directory: %v", err)
+	}
+	dir := t.TempDir()
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("failed to change directory: %v", err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+
+	var out bytes.Buffer
+	cmd := NewGenerateCmd(context.Background(), &out)
+	cmd.SetArgs([]string{
+		fixture,
+		"--selector", "#standings",
+		"--package-name", "standings",
+		"--struct-name", "Standing",
+		"--file-name", "standings",
+	})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("generate failed: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		file     string
+		contains []string
+	}{
+		{
+			name: "struct",
+			file: "standings.go",
+			contains: []string{
+				"package standings",
+				"type Standing struct",
+				"Team string",
+				"Wins int",
+			},
+		},
+		{
+			name: "test",
+			file: "standings_test.go",
+			contains: []string{
+				"package standings",
+				"//go:embed standings.html",
+				"func TestStanding(t *testing.T)",
+				"seltabl.NewFromString[Standing](StandingFixture)",
+				"assert.Len(t, rows, 2)",
+			},
+		},
+		{
+			name:     "fixture",
+			file:     "standings.html",
+			contains: []string{`<table id="standings">`, "Iowa City"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, err := os.ReadFile(filepath.Join(dir, tt.file))
+			if err != nil {
+				t.Fatalf("failed to read %s: %v", tt.file, err)
+			}
+			for _, want := range tt.contains {
+				if !strings.Contains(string(content), want) {
+					t.Errorf("%s does not contain %q:\n%s", tt.file, want, content)
+				}
+			}
+			if !strings.Contains(out.String(), "wrote file: "+tt.file) {
+				t.Errorf("output does not report writing %s:\n%s", tt.file, out.String())
+			}
+		})
+	}
+}
+
+func TestGenerateInvalidStructName(t *testing.T) {
+	tests := []struct {
+		name       string
+		structName string
+	}{
+		{"leading digit", "1Table"},
+		{"hyphen", "my-table"},
+		{"keyword", "type"},
+		{"empty", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := NewGenerateCmd(context.Background(), &bytes.Buffer{})
+			cmd.SetArgs([]string{"missing.html", "--struct-name", tt.structName})
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true
+			err := cmd.Execute()
+			if err == nil || !strings.Contains(err.Error(), "not a valid go identifier") {
+				t.Errorf("expected invalid struct name error, got %v", err)
+			}
+		})
+	}
+}
@@ -37,6 +37,10 @@ func AddCommands(
 	root.AddCommand(cmds.NewStaticCmd(
 		ctx,
 	))
+	root.AddCommand(cmds.NewGenerateCmd(
+		ctx,
+		os.Stdout,
+	))
 	root.AddCommand(cmds.NewVersionCmd())
 	return nil
 }
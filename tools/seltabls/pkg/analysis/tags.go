@@ -29,6 +29,144 @@ var (
 		Documentation: "The must be present selector is used to ensure that the given raw text is present in the html content.",
 		Kind:          protocol.CompletionItemKindField,
 	}
+	// selectorFollowTag is the tag used to follow a link in a cell to a page decoded into the field
+	selectorFollowTag = protocol.CompletionItem{Label: "follow",
+		Detail:        "Title Text for the follow tag",
+		Documentation: "The follow tag, such as \"a@href\", follows the link in the cell and decodes the linked page into the struct, pointer or slice field.",
+		Kind:          protocol.CompletionItemKindField,
+	}
+	// validateRequiredTag is the tag used to ensure a cell is not empty
+	validateRequiredTag = protocol.CompletionItem{Label: "required",
+		Detail:        "Title Text for the required validation",
+		Documentation: "The required validation is used to ensure that the selected value of the cell is not empty.",
+		Kind:          protocol.CompletionItemKindField,
+	}
+	// validatePatternTag is the tag used to ensure a cell matches a regular expression
+	validatePatternTag = protocol.CompletionItem{Label: "pattern",
+		Detail:        "Title Text for the pattern validation",
+		Documentation: "The pattern validation is used to ensure that the selected value of the cell matches the given regular expression.",
+		Kind:          protocol.CompletionItemKindField,
+	}
+	// validateMinTag is the tag used to ensure a number or length is at least a minimum
+	validateMinTag = protocol.CompletionItem{Label: "min",
+		Detail:        "Title Text for the min validation",
+		Documentation: "The min validation is used to ensure that a number field, or the length of a string field, is at least the given minimum.",
+		Kind:          protocol.CompletionItemKindField,
+	}
+	// validateMaxTag is the tag used to ensure a number or length is at most a maximum
+	validateMaxTag = protocol.CompletionItem{Label: "max",
+		Detail:        "Title Text for the max validation",
+		Documentation: "The max validation is used to ensure that a number field, or the length of a string field, is at most the given maximum.",
+		Kind:          protocol.CompletionItemKindField,
+	}
+	// validateOneOfTag is the tag used to ensure a cell is one of a list of values
+	validateOneOfTag = protocol.CompletionItem{Label: "oneof",
+		Detail:        "Title Text for the oneof validation",
+		Documentation: "The oneof validation is used to ensure that the selected value of the cell is one of the given space separated values.",
+		Kind:          protocol.CompletionItemKindField,
+	}
+	// transformTag is the tag used to transform the selected value of a cell
+	transformTag = protocol.CompletionItem{Label: "fn",
+		Detail:        "Title Text for the transform tag",
+		Documentation: "The fn tag is a comma separated list of transforms, such as \"strip-footnotes,collapse-whitespace\", applied to the selected value before it is validated and converted to the type of the field.",
+		Kind:          protocol.CompletionItemKindField,
+	}
+	// numberLocaleTag is the tag used to name the locale numbers in a cell are written in
+	numberLocaleTag = protocol.CompletionItem{Label: "locale",
+		Detail:        "Title Text for the locale tag",
+		Documentation: "The locale tag names the locale numbers in the cell are written in, such as \"de\" for 1.234,56. Defaults to english.",
+		Kind:          protocol.CompletionItemKindField,
+	}
+	// mapTag is the tag used to map the value of a cell onto another value
+	mapTag = protocol.CompletionItem{Label: "map",
+		Detail:        "Title Text for the map tag",
+		Documentation: "The map tag maps the selected value of the cell onto another value using comma separated key=value entries, failing for unknown values.",
+		Kind:          protocol.CompletionItemKindField,
+	}
+	// boolTruthyTag is the tag used to list the values of a bool field that are true
+	boolTruthyTag = protocol.CompletionItem{Label: "true",
+		Detail:        "Title Text for the true tag",
+		Documentation: "The true tag lists the space separated values of the cell that decode a bool field as true.",
+		Kind:          protocol.CompletionItemKindField,
+	}
+	// boolFalsyTag is the tag used to list the values of a bool field that are false
+	boolFalsyTag = protocol.CompletionItem{Label: "false",
+		Detail:        "Title Text for the false tag",
+		Documentation: "The false tag lists the space separated values of the cell that decode a bool field as false.",
+		Kind:          protocol.CompletionItemKindField,
+	}
+	// defaultTag is the tag used to give the value of an empty or missing cell
+	defaultTag = protocol.CompletionItem{Label: "default",
+		Detail:        "Title Text for the default tag",
+		Documentation: "The default tag gives the value used when the selected cell is empty or the data selector matches fewer rows than other fields.",
+		Kind:          protocol.CompletionItemKindField,
+	}
+	// rowSkipTag is the tag used on a blank field to skip the first rows of a struct
+	rowSkipTag = protocol.CompletionItem{Label: "skip",
+		Detail:        "Title Text for the skip tag",
+		Documentation: "The skip tag, on a blank field, skips the given number of rows at the start of the table for every field.",
+		Kind:          protocol.CompletionItemKindField,
+	}
+	// rowSkipSelectorTag is the tag used on a blank field to skip rows matching a selector
+	rowSkipSelectorTag = protocol.CompletionItem{Label: "skipSel",
+		Detail:        "Title Text for the skip selector",
+		Documentation: "The skip selector, on a blank field, skips rows matching or within an element matching the selector for every field.",
+		Kind:          protocol.CompletionItemKindField,
+	}
+	// rowKeepSelectorTag is the tag used on a blank field to keep only rows matching a selector
+	rowKeepSelectorTag = protocol.CompletionItem{Label: "keepSel",
+		Detail:        "Title Text for the keep selector",
+		Documentation: "The keep selector, on a blank field, keeps only rows matching or within an element matching the selector for every field.",
+		Kind:          protocol.CompletionItemKindField,
+	}
+	// fillTag is the tag used to fill empty or missing cells
+	fillTag = protocol.CompletionItem{Label: "fill",
+		Detail:        "Title Text for the fill tag",
+		Documentation: "The fill tag, when \"down\", fills an empty or missing cell with the closest non-empty cell above it.",
+		Kind:          protocol.CompletionItemKindField,
+	}
+	// selectorGroupTag is the tag used to select group cells filling the rows after them
+	selectorGroupTag = protocol.CompletionItem{Label: "group",
+		Detail:        "Title Text for the group selector",
+		Documentation: "The group selector selects group cells, such as section header rows, used instead of the data selector. The field of each row is selected from the last group cell before the row.",
+		Kind:          protocol.CompletionItemKindField,
+	}
+	// rowStrideTag is the tag used on a blank field to decode a struct from several rows
+	rowStrideTag = protocol.CompletionItem{Label: "stride",
+		Detail:        "Title Text for the stride tag",
+		Documentation: "The stride tag, on a blank field, decodes each struct from the given number of rows, such as a summary row followed by a detail row.",
+		Kind:          protocol.CompletionItemKindField,
+	}
+	// selectorSubRowTag is the tag used to select which row of a struct with a stride holds the field
+	selectorSubRowTag = protocol.CompletionItem{Label: "sub",
+		Detail:        "Title Text for the sub row tag",
+		Documentation: "The sub row tag selects which of the rows of a struct with a stride the cells of the field are in, counting from 0.",
+		Kind:          protocol.CompletionItemKindField,
+	}
+	// selectorKeyTag is the tag used to select the cell labelled by a key in a vertical table
+	selectorKeyTag = protocol.CompletionItem{Label: "key",
+		Detail:        "Title Text for the key selector",
+		Documentation: "The key selector selects the cell labelled by the given key in a vertical table, such as an infobox, when decoding a single struct with NewOne.",
+		Kind:          protocol.CompletionItemKindField,
+	}
+	// pivotTag is the tag used to mark the key and value fields of unpivoted columns
+	pivotTag = protocol.CompletionItem{Label: "pivot",
+		Detail:        "Title Text for the pivot tag",
+		Documentation: "The pivot tag, \"key\" or \"value\", unpivots columns into a row per column. The key is selected from the header cells of its header selector and the value from the cells of its data selector in the same column.",
+		Kind:          protocol.CompletionItemKindField,
+	}
+	// selectorDialectTag is the tag used to name the dialect of the selectors of a field
+	selectorDialectTag = protocol.CompletionItem{Label: "dialect",
+		Detail:        "Title Text for the dialect tag",
+		Documentation: "The dialect tag, \"xpath\", writes the header, data and count query selectors of the field in XPath instead of css. Selectors prefixed with \"xpath:\" are XPath whatever the dialect.",
+		Kind:          protocol.CompletionItemKindField,
+	}
+	// rowSelectorTag is the tag used on a blank field to select the row elements of a struct
+	rowSelectorTag = protocol.CompletionItem{Label: "rowSel",
+		Detail:        "Title Text for the row selector",
+		Documentation: "The row selector, on a blank field, selects the row elements of the struct, such as \"div.card\". The data selectors of the fields are relative to each row element and select the first matching element in it.",
+		Kind:          protocol.CompletionItemKindField,
+	}
 	// selectorControlTag is the tag used to signify selecting aspects of a cell
 	selectorControlTag = protocol.CompletionItem{Label: "ctl",
 		Detail:        "Title Text for the control selector",
@@ -41,6 +179,29 @@ var (
 		selectorHeaderTag,
 		selectorQueryTag,
 		selectorMustBePresentTag,
+		selectorFollowTag,
+		validateRequiredTag,
+		validatePatternTag,
+		validateMinTag,
+		validateMaxTag,
+		validateOneOfTag,
+		transformTag,
+		numberLocaleTag,
+		mapTag,
+		boolTruthyTag,
+		boolFalsyTag,
+		defaultTag,
+		rowSkipTag,
+		rowSkipSelectorTag,
+		rowKeepSelectorTag,
+		fillTag,
+		selectorGroupTag,
+		rowStrideTag,
+		selectorSubRowTag,
+		selectorKeyTag,
+		pivotTag,
+		selectorDialectTag,
+		rowSelectorTag,
 		selectorControlTag,
 	}
 )
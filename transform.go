@@ -0,0 +1,216 @@
+package seltabl
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// transform transforms a value selected from a cell.
+type transform func(value string) (string, error)
+
+var (
+	// footnotesPattern matches footnote markers such as [1], [a] or
+	// [citation needed].
+	footnotesPattern = regexp.MustCompile(`\[[^\[\]]*\]`)
+
+	// pipelines caches parsed fn tags by their value.
+	pipelines sync.Map
+
+	// transforms are the transforms usable in a fn tag by name.
+	//
+	// Each takes the arguments given in parentheses after its name.
+	transforms = map[string]func(args []string) (transform, error){
+		"trim": func(args []string) (transform, error) {
+			return func(value string) (string, error) {
+				return strings.TrimSpace(value), nil
+			}, wantArgs("trim", args, 0)
+		},
+		"collapse-whitespace": func(args []string) (transform, error) {
+			return func(value string) (string, error) {
+				return strings.Join(strings.Fields(value), " "), nil
+			}, wantArgs("collapse-whitespace", args, 0)
+		},
+		"lower": func(args []string) (transform, error) {
+			return func(value string) (string, error) {
+				return strings.ToLower(value), nil
+			}, wantArgs("lower", args, 0)
+		},
+		"upper": func(args []string) (transform, error) {
+			return func(value string) (string, error) {
+				return strings.ToUpper(value), nil
+			}, wantArgs("upper", args, 0)
+		},
+		"strip-footnotes": func(args []string) (transform, error) {
+			return func(value string) (string, error) {
+				return footnotesPattern.ReplaceAllString(value, ""), nil
+			}, wantArgs("strip-footnotes", args, 0)
+		},
+		"replace": func(args []string) (transform, error) {
+			if err := wantArgs("replace", args, 2); err != nil {
+				return nil, err
+			}
+			return func(value string) (string, error) {
+				return strings.ReplaceAll(value, args[0], args[1]), nil
+			}, nil
+		},
+		"regex": func(args []string) (transform, error) {
+			if err := wantArgs("regex", args, 1); err != nil {
+				return nil, err
+			}
+			re, err := compilePattern(args[0])
+			if err != nil {
+				return nil, err
+			}
+			return func(value string) (string, error) {
+				match := re.FindStringSubmatch(value)
+				switch {
+				case match == nil:
+					return "", nil
+				case len(match) > 1:
+					return match[1], nil
+				default:
+					return match[0], nil
+				}
+			}, nil
+		},
+		"split": func(args []string) (transform, error) {
+			if err := wantArgs("split", args, 2); err != nil {
+				return nil, err
+			}
+			index, err := strconv.Atoi(args[1])
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse split index %s: %w", args[1], err)
+			}
+			return func(value string) (string, error) {
+				parts := strings.Split(value, args[0])
+				i := index
+				if i < 0 {
+					i += len(parts)
+				}
+				if i < 0 || i >= len(parts) {
+					return "", nil
+				}
+				return parts[i], nil
+			}, nil
+		},
+	}
+)
+
+// wantArgs returns an error if a transform was not given the number of
+// arguments it takes.
+func wantArgs(name string, args []string, n int) error {
+	if len(args) != n {
+		return fmt.Errorf("transform %s takes %d arguments, got %d", name, n, len(args))
+	}
+	return nil
+}
+
+// applyTransforms runs the value through the transforms of a fn tag.
+//
+// A fn tag is a comma separated list of transforms applied in order, each
+// optionally followed by arguments in parentheses. Arguments containing
+// commas or parentheses can be single quoted.
+//
+// Example:
+//
+//	fn:"strip-footnotes,collapse-whitespace,replace('$',''),split(' ',0)"
+func applyTransforms(spec string, value string) (string, error) {
+	if spec == "" {
+		return value, nil
+	}
+	pipeline, err := parseTransforms(spec)
+	if err != nil {
+		return "", err
+	}
+	for _, t := range pipeline {
+		value, err = t(value)
+		if err != nil {
+			return "", err
+		}
+	}
+	return value, nil
+}
+
+// parseTransforms parses a fn tag into its transforms, caching the result.
+func parseTransforms(spec string) ([]transform, error) {
+	if pipeline, ok := pipelines.Load(spec); ok {
+		return pipeline.([]transform), nil
+	}
+	steps, err := splitTopLevel(spec)
+	if err != nil {
+		return nil, err
+	}
+	pipeline := make([]transform, 0, len(steps))
+	for _, step := range steps {
+		name, rawArgs, hasArgs := strings.Cut(step, "(")
+		name = strings.TrimSpace(name)
+		var args []string
+		if hasArgs {
+			if !strings.HasSuffix(rawArgs, ")") {
+				return nil, fmt.Errorf("unterminated arguments for transform %s", name)
+			}
+			rawArgs = strings.TrimSuffix(rawArgs, ")")
+			switch {
+			case strings.TrimSpace(rawArgs) == "":
+			case name == "regex":
+				args = []string{strings.TrimSpace(rawArgs)}
+			default:
+				if args, err = splitTopLevel(rawArgs); err != nil {
+					return nil, err
+				}
+			}
+			for i := range args {
+				args[i] = unquote(args[i])
+			}
+		}
+		newTransform, ok := transforms[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown transform: %s", name)
+		}
+		t, err := newTransform(args)
+		if err != nil {
+			return nil, err
+		}
+		pipeline = append(pipeline, t)
+	}
+	pipelines.Store(spec, pipeline)
+	return pipeline, nil
+}
+
+// splitTopLevel splits the string on commas that are not in parentheses or
+// single quotes, trimming space around each part.
+func splitTopLevel(s string) ([]string, error) {
+	parts := make([]string, 0)
+	depth := 0
+	quoted := false
+	start := 0
+	for i, r := range s {
+		switch {
+		case r == '\'':
+			quoted = !quoted
+		case quoted:
+		case r == '(':
+			depth++
+		case r == ')':
+			depth--
+		case r == ',' && depth == 0:
+			parts = append(parts, strings.TrimSpace(s[start:i]))
+			start = i + 1
+		}
+	}
+	if quoted || depth != 0 {
+		return nil, fmt.Errorf("unbalanced quotes or parentheses in %s", s)
+	}
+	return append(parts, strings.TrimSpace(s[start:])), nil
+}
+
+// unquote removes the single quotes surrounding a transform argument.
+func unquote(arg string) string {
+	if len(arg) >= 2 && arg[0] == '\'' && arg[len(arg)-1] == '\'' {
+		return arg[1 : len(arg)-1]
+	}
+	return arg
+}
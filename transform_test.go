@@ -0,0 +1,71 @@
+package seltabl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestApplyTransforms tests running values through fn tags.
+func TestApplyTransforms(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		spec    string
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{name: "empty", spec: "", value: " a ", want: " a "},
+		{name: "trim", spec: "trim", value: " a ", want: "a"},
+		{name: "collapse whitespace", spec: "collapse-whitespace", value: " a  \n b ", want: "a b"},
+		{name: "lower", spec: "lower", value: "ABC", want: "abc"},
+		{name: "upper", spec: "upper()", value: "abc", want: "ABC"},
+		{name: "strip footnotes", spec: "strip-footnotes,trim", value: "1,234[1][note a]", want: "1,234"},
+		{name: "replace", spec: "replace(',','')", value: "1,234,567", want: "1234567"},
+		{name: "regex group", spec: `regex('(\d+) pts')`, value: "about 12 pts", want: "12"},
+		{name: "regex unquoted", spec: `regex(\d{1,3})`, value: "ab 1234", want: "123"},
+		{name: "regex no match", spec: `regex(\d+)`, value: "none", want: ""},
+		{name: "split", spec: "split(' - ',1)", value: "Ames - Iowa", want: "Iowa"},
+		{name: "split negative", spec: "split('/',-1)", value: "a/b/c", want: "c"},
+		{name: "split out of range", spec: "split('/',5)", value: "a/b", want: ""},
+		{name: "chain", spec: "strip-footnotes, collapse-whitespace, lower", value: " Big  Ten[2] ", want: "big ten"},
+		{name: "unknown", spec: "reverse", value: "a", wantErr: true},
+		{name: "wrong args", spec: "replace(a)", value: "a", wantErr: true},
+		{name: "bad split index", spec: "split(a,b)", value: "a", wantErr: true},
+		{name: "unbalanced", spec: "replace('a,b)", value: "a", wantErr: true},
+		{name: "bad regex", spec: "regex('(')", value: "a", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := applyTransforms(tt.spec, tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// TestNewFromString_Transforms tests that transforms are applied before
+// values are converted.
+func TestNewFromString_Transforms(t *testing.T) {
+	t.Parallel()
+	type Penguin struct {
+		Name       string  `dSel:"tr td:nth-child(1)" fn:"strip-footnotes,collapse-whitespace"`
+		Population int     `dSel:"tr td:nth-child(2)" fn:"strip-footnotes,replace(',','')"`
+		Mass       float64 `dSel:"tr td:nth-child(3)" fn:"split('–',1)"`
+	}
+	result, err := NewFromString[Penguin](`
+		<table>
+			<tr>
+				<td>Emperor&nbsp;penguin<sup>[3]</sup></td>
+				<td>595,000[4]</td>
+				<td>22–45</td>
+			</tr>
+		</table>`)
+	assert.NoError(t, err)
+	assert.Equal(t, []Penguin{{Name: "Emperor penguin", Population: 595000, Mass: 45}}, result)
+}
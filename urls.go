@@ -0,0 +1,87 @@
+package seltabl
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+const (
+	// srcsetAttr is the attribute holding a list of image candidates.
+	srcsetAttr = "srcset"
+)
+
+// documentBase returns the url relative urls in the document are resolved
+// against.
+//
+// The given base, or the document's url if no base is given, is used as the
+// url of the document. A <base href> element in the document is resolved
+// against it and takes precedence.
+func documentBase(doc *goquery.Document, base *url.URL) *url.URL {
+	if base == nil {
+		base = doc.Url
+	}
+	href, exists := doc.Find("base[href]").First().Attr("href")
+	if !exists {
+		return base
+	}
+	baseHref, err := url.Parse(strings.TrimSpace(href))
+	if err != nil {
+		return base
+	}
+	if base == nil {
+		return baseHref
+	}
+	return base.ResolveReference(baseHref)
+}
+
+// documentURL returns the url of the document.
+//
+// It is the given base, or the document's url if no base is given. An empty
+// string is returned if the document has no url.
+func documentURL(doc *goquery.Document, base *url.URL) string {
+	if base == nil {
+		base = doc.Url
+	}
+	if base == nil {
+		return ""
+	}
+	return base.String()
+}
+
+// resolveURL resolves the possibly relative url against the base.
+//
+// If the base is nil the url is returned unchanged.
+func resolveURL(base *url.URL, rawURL string) (string, error) {
+	rawURL = strings.TrimSpace(rawURL)
+	if base == nil {
+		return rawURL, nil
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse url %s: %w", rawURL, err)
+	}
+	return base.ResolveReference(u).String(), nil
+}
+
+// resolveSrcset resolves every url in a srcset attribute value against the
+// base keeping the width and density descriptors.
+func resolveSrcset(base *url.URL, srcset string) (string, error) {
+	candidates := strings.Split(srcset, ",")
+	resolved := make([]string, 0, len(candidates))
+	for _, candidate := range candidates {
+		parts := strings.Fields(candidate)
+		if len(parts) == 0 {
+			continue
+		}
+		u, err := resolveURL(base, parts[0])
+		if err != nil {
+			return "", err
+		}
+		parts[0] = u
+		resolved = append(resolved, strings.Join(parts, " "))
+	}
+	return strings.Join(resolved, ", "), nil
+}
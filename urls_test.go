@@ -0,0 +1,116 @@
+package seltabl
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// LinkStruct is a test struct selecting absolute urls.
+type LinkStruct struct {
+	Team  string `dSel:"tr td:nth-child(1)" ctl:"text"`
+	Link  string `dSel:"tr td:nth-child(1) a" ctl:"url"`
+	Image string `dSel:"tr td:nth-child(2) img" ctl:"url" qSel:"srcset"`
+}
+
+// linkHTML is the html decoded into LinkStruct.
+const linkHTML = `
+	<table>
+		<tr>
+			<td><a href="/teams/42">Cyclones</a></td>
+			<td><img srcset="logo.png 1x, /img/logo@2x.png 2x"></td>
+		</tr>
+	</table>`
+
+// TestNewFromString_URLControl tests resolving urls against the base url
+// option.
+func TestNewFromString_URLControl(t *testing.T) {
+	t.Parallel()
+	base, err := url.Parse("https://example.com/sports/index.html")
+	assert.NoError(t, err)
+	result, err := NewFromString[LinkStruct](linkHTML, WithBaseURL(base))
+	assert.NoError(t, err)
+	assert.Equal(t, []LinkStruct{{
+		Team:  "Cyclones",
+		Link:  "https://example.com/teams/42",
+		Image: "https://example.com/sports/logo.png 1x, https://example.com/img/logo@2x.png 2x",
+	}}, result)
+}
+
+// TestNewFromString_URLControlWithoutBase tests that urls are left as is
+// without a base url.
+func TestNewFromString_URLControlWithoutBase(t *testing.T) {
+	t.Parallel()
+	result, err := NewFromString[LinkStruct](linkHTML)
+	assert.NoError(t, err)
+	assert.Equal(t, "/teams/42", result[0].Link)
+}
+
+// TestNewFromString_URLControlBaseHref tests that a <base href> takes
+// precedence over the document url.
+func TestNewFromString_URLControlBaseHref(t *testing.T) {
+	t.Parallel()
+	base, err := url.Parse("https://example.com/sports/")
+	assert.NoError(t, err)
+	result, err := NewFromString[LinkStruct](
+		`<head><base href="/mirror/"></head>`+linkHTML,
+		WithBaseURL(base),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://example.com/teams/42", result[0].Link)
+	assert.Equal(
+		t,
+		"https://example.com/mirror/logo.png 1x, https://example.com/img/logo@2x.png 2x",
+		result[0].Image,
+	)
+}
+
+// TestNewFromURL_URLControl tests that NewFromURL resolves urls against the
+// fetched url.
+func TestNewFromURL_URLControl(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintln(w, linkHTML)
+	}))
+	defer server.Close()
+	result, err := NewFromURL[LinkStruct](server.URL + "/sports/index.html")
+	assert.NoError(t, err)
+	assert.Equal(t, server.URL+"/teams/42", result[0].Link)
+}
+
+// TestNewFromString_AttributeChains tests that the query and url controls
+// select the first attribute of a chain the cell has.
+func TestNewFromString_AttributeChains(t *testing.T) {
+	t.Parallel()
+	type Chained struct {
+		ID    string `dSel:"tr td a" ctl:"query" qSel:"data-id || id"`
+		Link  string `dSel:"tr td a" ctl:"url" qSel:"data-href || href"`
+		Image string `dSel:"tr td img" ctl:"url" qSel:"data-src || srcset"`
+	}
+	base, err := url.Parse("https://example.com/sports/")
+	assert.NoError(t, err)
+	result, err := NewFromString[Chained](`
+		<table>
+			<tr><td>
+				<a id="a1" data-href="/teams/1" href="#">Ames</a>
+				<img srcset="logo.png 1x">
+			</td></tr>
+			<tr><td>
+				<a data-id="b2" id="b" href="/teams/2">Boone</a>
+				<img srcset="boone.png 1x, /img/boone@2x.png 2x">
+			</td></tr>
+		</table>`, WithBaseURL(base))
+	assert.NoError(t, err)
+	assert.Equal(t, []Chained{
+		{ID: "a1", Link: "https://example.com/teams/1", Image: "https://example.com/sports/logo.png 1x"},
+		{
+			ID:    "b2",
+			Link:  "https://example.com/teams/2",
+			Image: "https://example.com/sports/boone.png 1x, https://example.com/img/boone@2x.png 2x",
+		},
+	}, result)
+}
@@ -0,0 +1,160 @@
+package seltabl
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// patterns caches compiled regular expressions by their pattern.
+var patterns sync.Map
+
+// compilePattern compiles the regular expression, caching the result.
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	if re, ok := patterns.Load(pattern); ok {
+		return re.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile pattern %s: %w", pattern, err)
+	}
+	patterns.Store(pattern, re)
+	return re, nil
+}
+
+// validateDocument checks the must tags of the struct's fields against the
+// document.
+func validateDocument(doc *goquery.Document, dType reflect.Type) error {
+	var content string
+	for i := 0; i < dType.NumField(); i++ {
+		must := dType.Field(i).Tag.Get(validateMustTag)
+		if must == "" {
+			continue
+		}
+		if content == "" {
+			var err error
+			content, err = doc.Html()
+			if err != nil {
+				return fmt.Errorf("failed to render document: %w", err)
+			}
+		}
+		if !strings.Contains(content, must) && !strings.Contains(doc.Text(), must) {
+			return ErrValidation{
+				Row:   -1,
+				Field: dType.Field(i),
+				Tag:   validateMustTag,
+				Rule:  must,
+			}
+		}
+	}
+	return nil
+}
+
+// hasValidation reports whether the field has a required, pattern, oneof,
+// min or max tag.
+func hasValidation(cfg *SelectorConfig) bool {
+	return cfg.Required || cfg.Pattern != "" || cfg.OneOf != "" ||
+		cfg.Min != "" || cfg.Max != ""
+}
+
+// validateValue checks the value selected for a field against the field's
+// required, pattern and oneof tags.
+func validateValue(
+	cfg *SelectorConfig,
+	structField reflect.StructField,
+	value string,
+) error {
+	fail := func(tag, rule string) error {
+		return ErrValidation{
+			Field: structField,
+			Tag:   tag,
+			Rule:  rule,
+			Value: value,
+		}
+	}
+	if cfg.Required && strings.TrimSpace(value) == "" {
+		return fail(validateRequiredTag, "true")
+	}
+	if cfg.Pattern != "" {
+		re, err := compilePattern(cfg.Pattern)
+		if err != nil {
+			return err
+		}
+		if !re.MatchString(value) {
+			return fail(validatePatternTag, cfg.Pattern)
+		}
+	}
+	if cfg.OneOf != "" {
+		found := false
+		for _, option := range strings.Fields(cfg.OneOf) {
+			if value == option {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fail(validateOneOfTag, cfg.OneOf)
+		}
+	}
+	return nil
+}
+
+// validateRange checks the value set on a field against the field's min and
+// max tags.
+//
+// Numbers are compared by value and strings by their length.
+func validateRange(
+	cfg *SelectorConfig,
+	structField reflect.StructField,
+	field reflect.Value,
+	value string,
+) error {
+	if cfg.Min == "" && cfg.Max == "" {
+		return nil
+	}
+	var n float64
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n = float64(field.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n = float64(field.Uint())
+	case reflect.Float32, reflect.Float64:
+		n = field.Float()
+	case reflect.String:
+		n = float64(utf8.RuneCountInString(field.String()))
+	default:
+		return fmt.Errorf("min and max are unsupported for type: %s", field.Kind())
+	}
+	bounds := []struct {
+		tag  string
+		rule string
+		fail func(bound float64) bool
+	}{
+		{validateMinTag, cfg.Min, func(bound float64) bool { return n < bound }},
+		{validateMaxTag, cfg.Max, func(bound float64) bool { return n > bound }},
+	}
+	for _, b := range bounds {
+		if b.rule == "" {
+			continue
+		}
+		bound, err := strconv.ParseFloat(b.rule, 64)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s tag %s: %w", b.tag, b.rule, err)
+		}
+		if b.fail(bound) {
+			return ErrValidation{
+				Field: structField,
+				Tag:   b.tag,
+				Rule:  b.rule,
+				Value: value,
+			}
+		}
+	}
+	return nil
+}
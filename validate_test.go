@@ -0,0 +1,116 @@
+package seltabl
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// validateHTML is the html decoded by the validation tests.
+const validateHTML = `
+	<h1>Standings</h1>
+	<table>
+		<tr> <td>Team</td> <td>Wins</td> <td>Result</td> </tr>
+		<tr> <td>Ames</td> <td>12</td> <td>W</td> </tr>
+		<tr> <td></td> <td>40</td> <td>X</td> </tr>
+	</table>`
+
+// TestNewFromString_Validation tests the validation tags.
+func TestNewFromString_Validation(t *testing.T) {
+	t.Parallel()
+	type Valid struct {
+		Team string `dSel:"tr:nth-child(2) td:nth-child(1)" must:"Standings" required:"true" pattern:"^[A-Z]" min:"2" max:"10"`
+		Wins int    `dSel:"tr:nth-child(2) td:nth-child(2)" min:"0" max:"20"`
+		Res  string `dSel:"tr:nth-child(2) td:nth-child(3)" oneof:"W L T"`
+	}
+	result, err := NewFromString[Valid](validateHTML)
+	assert.NoError(t, err)
+	assert.Equal(t, []Valid{{Team: "Ames", Wins: 12, Res: "W"}}, result)
+
+	type Must struct {
+		Team string `dSel:"tr td:nth-child(1)" must:"Conference"`
+	}
+	type Required struct {
+		Team string `dSel:"tr td:nth-child(1)" required:"true"`
+	}
+	type Pattern struct {
+		Wins string `dSel:"tr td:nth-child(2)" pattern:"^\\d+$"`
+	}
+	type Max struct {
+		Wins int `dSel:"tr:not(:first-child) td:nth-child(2)" max:"20"`
+	}
+	type OneOf struct {
+		Res string `dSel:"tr:not(:first-child) td:nth-child(3)" oneof:"W L T"`
+	}
+	type Missing struct {
+		A int    `dSel:"tr td.a"`
+		B string `dSel:"tr td.b" required:"true"`
+	}
+	missingHTML := `
+		<table>
+			<tr> <td class="a">1</td> <td class="b">x</td> </tr>
+			<tr> <td class="a">2</td> </tr>
+		</table>`
+	tests := []struct {
+		name string
+		fn   func() error
+		want ErrValidation
+	}{
+		{
+			name: "must",
+			fn:   func() error { _, err := NewFromString[Must](validateHTML); return err },
+			want: ErrValidation{Row: -1, Tag: "must", Rule: "Conference"},
+		},
+		{
+			name: "required",
+			fn:   func() error { _, err := NewFromString[Required](validateHTML); return err },
+			want: ErrValidation{Row: 2, Tag: "required", Rule: "true"},
+		},
+		{
+			name: "pattern",
+			fn:   func() error { _, err := NewFromString[Pattern](validateHTML); return err },
+			want: ErrValidation{Row: 0, Tag: "pattern", Rule: "^\\d+$", Value: "Wins"},
+		},
+		{
+			name: "max",
+			fn:   func() error { _, err := NewFromString[Max](validateHTML); return err },
+			want: ErrValidation{Row: 1, Tag: "max", Rule: "20", Value: "40"},
+		},
+		{
+			name: "oneof",
+			fn:   func() error { _, err := NewFromString[OneOf](validateHTML); return err },
+			want: ErrValidation{Row: 1, Tag: "oneof", Rule: "W L T", Value: "X"},
+		},
+		{
+			name: "missing cell",
+			fn: func() error {
+				_, err := NewFromString[Missing](missingHTML, WithAlignment(AlignByRow))
+				return err
+			},
+			want: ErrValidation{Row: 1, Tag: "required", Rule: "true"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got ErrValidation
+			err := tt.fn()
+			assert.True(t, errors.As(err, &got), "expected ErrValidation, got %v", err)
+			assert.Equal(t, tt.want.Row, got.Row)
+			assert.Equal(t, tt.want.Tag, got.Tag)
+			assert.Equal(t, tt.want.Rule, got.Rule)
+			assert.Equal(t, tt.want.Value, got.Value)
+			assert.NotEmpty(t, got.Field.Name)
+		})
+	}
+}
+
+// TestErrValidation_Error tests the Error method of ErrValidation.
+func TestErrValidation_Error(t *testing.T) {
+	t.Parallel()
+	err := ErrValidation{Row: 3, Tag: "oneof", Rule: "W L", Value: "X"}
+	assert.Contains(t, err.Error(), "row 3")
+	assert.Contains(t, err.Error(), `"X"`)
+	err.Row = -1
+	assert.Contains(t, err.Error(), "document fails")
+}
@@ -0,0 +1,153 @@
+package seltabl
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Variant is a struct type that rows matching its discriminator are decoded
+// into by NewVariants.
+type Variant struct {
+	typ      reflect.Type // struct type of the variant
+	selector string       // discriminator selector
+	value    string       // text of the discriminator, if any
+}
+
+// NewVariant returns a variant decoding rows into T when its discriminator
+// matches them.
+//
+// The discriminator matches a row when the selector matches the row element
+// or an element within it and, if the value is not empty, the text of a
+// matched element is the value. An empty selector matches every row, such as
+// for a last catch-all variant.
+func NewVariant[T any](selector, value string) Variant {
+	return Variant{
+		typ:      reflect.TypeOf((*T)(nil)).Elem(),
+		selector: selector,
+		value:    value,
+	}
+}
+
+// matches reports whether the discriminator of the variant matches the row.
+func (v Variant) matches(row *goquery.Selection) bool {
+	if v.selector == "" {
+		return true
+	}
+	matched := find(row, v.selector)
+	if !strings.HasPrefix(v.selector, xpathPrefix) && row.Is(v.selector) {
+		matched = matched.AddSelection(row)
+	}
+	if v.value == "" {
+		return matched.Length() > 0
+	}
+	for i := range matched.Nodes {
+		if cellText(matched.Eq(i)) == v.value {
+			return true
+		}
+	}
+	return false
+}
+
+// NewVariants parses a goquery doc into a value for each row element matched
+// by the row selector, of the type of the first variant whose discriminator
+// matches the row. Rows matching no variant are skipped.
+//
+// The data selectors of the variants' fields are relative to each row, as
+// with the rowSel tag, and their row tags are ignored. The Index of a Meta
+// field is the index of the row in the results. I is the type of the
+// results, such as any or an interface sealing the variant types, which must
+// be assignable to it.
+//
+// Example:
+//
+//	type Row interface{ row() }
+//
+//	type Game struct {
+//		Home string `dSel:"td.home"`
+//		Away string `dSel:"td.away"`
+//	}
+//
+//	type Divider struct {
+//		Title string `dSel:"th"`
+//	}
+//
+//	func (Game) row()    {}
+//	func (Divider) row() {}
+//
+//	rows, err := seltabl.NewVariants[Row](doc, "table tr", []seltabl.Variant{
+//		seltabl.NewVariant[Divider]("th[colspan]", ""),
+//		seltabl.NewVariant[Game]("td.home", ""),
+//	})
+func NewVariants[I any](
+	doc *goquery.Document,
+	rowSelector string,
+	variants []Variant,
+	opts ...Option,
+) ([]I, error) {
+	iType := reflect.TypeOf((*I)(nil)).Elem()
+	for _, v := range variants {
+		if v.typ.Kind() != reflect.Struct {
+			return nil, fmt.Errorf("expected struct variant, got %s", v.typ)
+		}
+		if !v.typ.AssignableTo(iType) {
+			return nil, fmt.Errorf("variant %s is not assignable to %s", v.typ, iType)
+		}
+	}
+	o := newOptions(opts)
+	kinds := make([]int, 0)
+	groups := make([][]*goquery.Selection, len(variants))
+	find(doc.Selection, rowSelector).Each(func(_ int, row *goquery.Selection) {
+		for k, v := range variants {
+			if v.matches(row) {
+				kinds = append(kinds, k)
+				groups[k] = append(groups[k], row)
+				return
+			}
+		}
+	})
+	if len(kinds) == 0 {
+		return nil, errNoDataFound
+	}
+	decoded := make([]reflect.Value, len(variants))
+	for k, rows := range groups {
+		if len(rows) == 0 {
+			continue
+		}
+		var err error
+		decoded[k], err = decode(doc, variants[k].typ, o.forRows(rows))
+		if err != nil {
+			return nil, fmt.Errorf(
+				"failed to decode variant %s: %w",
+				variants[k].typ,
+				err,
+			)
+		}
+	}
+	results := make([]I, len(kinds))
+	next := make([]int, len(variants))
+	for j, k := range kinds {
+		row := decoded[k].Index(next[k])
+		setMetaIndex(row, j)
+		results[j] = row.Interface().(I)
+		next[k]++
+	}
+	return results, nil
+}
+
+// NewVariantsFromString parses a string into a value of a variant type for
+// each row element matched by the row selector.
+func NewVariantsFromString[I any](
+	htmlInput string,
+	rowSelector string,
+	variants []Variant,
+	opts ...Option,
+) ([]I, error) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(htmlInput))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse html: %w", err)
+	}
+	return NewVariants[I](doc, rowSelector, variants, opts...)
+}
@@ -0,0 +1,100 @@
+package seltabl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// variantsHTML is a table mixing divider, game and sponsored rows.
+const variantsHTML = `
+	<table>
+		<tr> <th colspan="2">Week 1</th> </tr>
+		<tr> <td class="home">Ames</td> <td class="away">Iowa</td> </tr>
+		<tr class="ad"> <td colspan="2">Sponsored: <a href="/buy">tickets</a></td> </tr>
+		<tr> <th colspan="2">Week 2</th> </tr>
+		<tr> <td class="home">Ohio</td> <td class="away">Penn</td> </tr>
+		<tr> <td colspan="2">Bye</td> </tr>
+	</table>`
+
+// variantRow is the sealed interface of the variant test types.
+type variantRow interface{ variantRow() }
+
+// variantGame is a game row.
+type variantGame struct {
+	Home string `dSel:"td.home"`
+	Away string `dSel:"td.away"`
+	Meta Meta
+}
+
+// variantDivider is a divider row.
+type variantDivider struct {
+	Title string `dSel:"th"`
+}
+
+func (variantGame) variantRow()    {}
+func (variantDivider) variantRow() {}
+
+// TestNewVariants tests decoding rows into the type of their variant.
+func TestNewVariants(t *testing.T) {
+	t.Parallel()
+	rows, err := NewVariantsFromString[variantRow](variantsHTML, "table tr", []Variant{
+		NewVariant[variantDivider]("th[colspan]", ""),
+		NewVariant[variantGame]("td.home", ""),
+	})
+	assert.NoError(t, err)
+	if !assert.Len(t, rows, 4) {
+		return
+	}
+	assert.Equal(t, variantDivider{Title: "Week 1"}, rows[0])
+	assert.Equal(t, "Ames", rows[1].(variantGame).Home)
+	assert.Contains(t, rows[1].(variantGame).Meta.HTML, "Iowa")
+	assert.Equal(t, variantDivider{Title: "Week 2"}, rows[2])
+	assert.Equal(t, "Penn", rows[3].(variantGame).Away)
+	assert.Equal(t, 1, rows[1].(variantGame).Meta.Index)
+	assert.Equal(t, 3, rows[3].(variantGame).Meta.Index)
+
+	type Ad struct {
+		Link string `dSel:"a" ctl:"query" qSel:"href"`
+	}
+	type Other struct {
+		Text string `dSel:"td"`
+	}
+	all, err := NewVariantsFromString[any](variantsHTML, "table tr", []Variant{
+		NewVariant[Ad]("tr.ad", ""),
+		NewVariant[Other]("td", "Bye"),
+		NewVariant[variantGame]("td.home", ""),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []any{
+		variantGame{Home: "Ames", Away: "Iowa"},
+		Ad{Link: "/buy"},
+		variantGame{Home: "Ohio", Away: "Penn"},
+		Other{Text: "Bye"},
+	}, clearMeta(all))
+
+	_, err = NewVariantsFromString[variantRow](variantsHTML, "table tr", []Variant{
+		NewVariant[Ad]("tr.ad", ""),
+	})
+	assert.ErrorContains(t, err, "is not assignable to")
+
+	type Missing struct {
+		Score string `dSel:"td.score"`
+	}
+	_, err = NewVariantsFromString[any](variantsHTML, "table tr", []Variant{
+		NewVariant[variantGame]("td.home", ""),
+		NewVariant[Missing]("", ""),
+	})
+	assert.ErrorContains(t, err, "failed to decode variant")
+}
+
+// clearMeta clears the Meta of the game rows.
+func clearMeta(rows []any) []any {
+	for i, row := range rows {
+		if game, ok := row.(variantGame); ok {
+			game.Meta = Meta{}
+			rows[i] = game
+		}
+	}
+	return rows
+}
@@ -0,0 +1,120 @@
+package seltabl
+
+import (
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/antchfx/htmlquery"
+	"golang.org/x/net/html"
+)
+
+const (
+	// xpathPrefix is the prefix of selectors written in XPath instead of
+	// css, such as "xpath://th[text()='Total']/following-sibling::td".
+	xpathPrefix = "xpath:"
+	// dialectXPath is the dialect tag value writing the field's selectors in
+	// XPath without the xpath prefix.
+	dialectXPath = "xpath"
+)
+
+// withDialect returns the selector, or each alternative of a selector chain,
+// prefixed for the dialect of the field.
+func withDialect(dialect, selector string) string {
+	if dialect != dialectXPath || selector == "" || selector == "-" {
+		return selector
+	}
+	alternatives := splitAlternatives(selector)
+	for i, alternative := range alternatives {
+		if !strings.HasPrefix(alternative, xpathPrefix) {
+			alternatives[i] = xpathPrefix + alternative
+		}
+	}
+	return strings.Join(alternatives, " "+alternativeSeparator+" ")
+}
+
+// find returns the elements matched by the selector within the selection.
+//
+// The alternatives of a selector chain are tried in order and the first one
+// matching any elements wins.
+func find(sel *goquery.Selection, selector string) *goquery.Selection {
+	var found *goquery.Selection
+	for _, alternative := range splitAlternatives(selector) {
+		found = findSelector(sel, alternative)
+		if found.Length() > 0 {
+			break
+		}
+	}
+	return found
+}
+
+// findSelector returns the elements matched by a single selector within the
+// selection.
+//
+// Selectors prefixed with "xpath:" are XPath expressions evaluated from each
+// element of the selection, so that absolute expressions such as "//td" match
+// anywhere in the document. Invalid expressions match nothing, as invalid css
+// selectors do.
+func findSelector(sel *goquery.Selection, selector string) *goquery.Selection {
+	expr, ok := strings.CutPrefix(selector, xpathPrefix)
+	if !ok {
+		return sel.Find(selector)
+	}
+	nodes := make([]*html.Node, 0)
+	for _, node := range sel.Nodes {
+		nodes = append(nodes, queryXPath(node, expr)...)
+	}
+	return sel.FilterNodes().AddNodes(nodes...)
+}
+
+// not returns the selection without the elements matched by the selector.
+//
+// The alternative of a selector chain used is the first one matching
+// anywhere in the document.
+func not(sel *goquery.Selection, selector string) *goquery.Selection {
+	selector, _ = chooseAlternative(document(sel), selector)
+	expr, ok := strings.CutPrefix(selector, xpathPrefix)
+	if !ok {
+		return sel.Not(selector)
+	}
+	return sel.NotNodes(queryXPath(rootNode(sel), expr)...)
+}
+
+// removeFiltered removes the elements of the selection matched by the
+// selector from the document.
+//
+// The alternative of a selector chain used is the first one matching
+// anywhere in the document.
+func removeFiltered(sel *goquery.Selection, selector string) {
+	selector, _ = chooseAlternative(document(sel), selector)
+	expr, ok := strings.CutPrefix(selector, xpathPrefix)
+	if !ok {
+		_ = sel.RemoveFiltered(selector)
+		return
+	}
+	_ = sel.FilterNodes(queryXPath(rootNode(sel), expr)...).Remove()
+}
+
+// queryXPath returns the nodes matched by the XPath expression from the
+// node, or nil if the expression is invalid.
+func queryXPath(node *html.Node, expr string) []*html.Node {
+	if node == nil {
+		return nil
+	}
+	nodes, err := htmlquery.QueryAll(node, expr)
+	if err != nil {
+		return nil
+	}
+	return nodes
+}
+
+// rootNode returns the document node of the selection.
+func rootNode(sel *goquery.Selection) *html.Node {
+	if sel.Length() == 0 {
+		return nil
+	}
+	node := sel.Get(0)
+	for node.Parent != nil {
+		node = node.Parent
+	}
+	return node
+}
@@ -0,0 +1,53 @@
+package seltabl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// xpathHTML is a table with a total row only addressable by its text.
+const xpathHTML = `
+	<table>
+		<tr> <th>Team</th> <th>Wins</th> </tr>
+		<tr> <td>Ames</td> <td>12</td> </tr>
+		<tr> <td>Iowa</td> <td>7</td> </tr>
+		<tr> <th>Total</th> <td>19</td> </tr>
+	</table>`
+
+// TestNewFromString_XPath tests selectors written in XPath.
+func TestNewFromString_XPath(t *testing.T) {
+	t.Parallel()
+	type Prefixed struct {
+		Team string `hSel:"xpath://tr[1]/th[1]" dSel:"xpath://tr[td]/*[1]"`
+		Wins int    `hSel:"tr:first-child th:nth-child(2)" dSel:"xpath://tr[td]/td[last()]"`
+	}
+	prefixed, err := NewFromString[Prefixed](xpathHTML)
+	assert.NoError(t, err)
+	assert.Equal(t, []Prefixed{
+		{Team: "Ames", Wins: 12},
+		{Team: "Iowa", Wins: 7},
+		{Team: "Total", Wins: 19},
+	}, prefixed)
+
+	type Dialect struct {
+		Team  string `dialect:"xpath" dSel:"//tr[not(th)]/td[1]"`
+		Links int    `dialect:"xpath" dSel:"//tr[not(th)]/td[1]" ctl:"count" qSel:".//a"`
+	}
+	dialect, err := NewFromString[Dialect](xpathHTML)
+	assert.NoError(t, err)
+	assert.Equal(t, []Dialect{{Team: "Ames"}, {Team: "Iowa"}}, dialect)
+
+	type Total struct {
+		Wins int `dSel:"xpath://th[text()='Total']/following-sibling::td"`
+	}
+	total, err := NewOneFromString[Total](xpathHTML)
+	assert.NoError(t, err)
+	assert.Equal(t, Total{Wins: 19}, total)
+
+	type Invalid struct {
+		Wins int `dSel:"xpath://tr[td"`
+	}
+	_, err = NewFromString[Invalid](xpathHTML)
+	assert.ErrorContains(t, err, "not found for field Wins")
+}